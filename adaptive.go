@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// answers with a correctness of at least gradeStepUp move the staircase up, at most gradeStepDown move it down
+	gradeStepUp   = 4
+	gradeStepDown = 2
+)
+
+const adaptiveNote = `
+
+ADAPTIVE DIFFICULTY
+   • Ask your next technical question at %s difficulty and tag the question
+     with its difficulty in "difficulty". Leave the tag out for anything that
+     is not a technical question.`
+
+// Staircase adapts the difficulty of the technical questions to the answers of the candidate.
+// A good answer moves the next question one level up, a poor answer one level down.
+type Staircase struct {
+	Level  int   // index into difficultyLevels of the next question
+	Levels []int // level of every graded question
+	Grades []int // grade of every graded answer
+}
+
+// staircase is the adaptive state of the current topic, nil if adaptive difficulty is disabled.
+var staircase *Staircase
+
+func newStaircase(startLevel int) *Staircase {
+	return &Staircase{Level: clamp(0, startLevel, len(difficultyLevels)-1)}
+}
+
+// Record stores the correctness grade of an answer to a question of the given level and moves the staircase.
+// Answers to questions of an unknown level are not recorded.
+func (s *Staircase) Record(level, grade int) {
+	if level < 0 || level >= len(difficultyLevels) {
+		return
+	}
+	grade = clamp(answerGradeMin, grade, answerGradeMax)
+	s.Levels = append(s.Levels, level)
+	s.Grades = append(s.Grades, grade)
+	switch {
+	case grade >= gradeStepUp:
+		s.Level = clamp(0, level+1, len(difficultyLevels)-1)
+	case grade <= gradeStepDown:
+		s.Level = clamp(0, level-1, len(difficultyLevels)-1)
+	default:
+		s.Level = level
+	}
+}
+
+// Graded returns the number of graded answers.
+func (s *Staircase) Graded() int {
+	return len(s.Grades)
+}
+
+// Estimate returns the score on the rubric the staircase points to.
+// Every answer demonstrates its question level plus the fraction of the grade,
+// the later half of the answers (closest to the candidate's actual level) is averaged.
+func (s *Staircase) Estimate(r Rubric) int {
+	if len(s.Grades) == 0 {
+		return 0
+	}
+	from := len(s.Grades) / 2
+	var ability float64
+	for i := from; i < len(s.Grades); i++ {
+		ability += float64(s.Levels[i]) + float64(s.Grades[i]-answerGradeMin)/float64(answerGradeMax-answerGradeMin)
+	}
+	ability /= float64(len(s.Grades) - from)
+	fraction := ability / float64(len(difficultyLevels))
+	return r.Clamp(r.Min + int(math.Round(fraction*float64(r.Max-r.Min))))
+}
+
+// Settle keeps the rating of the model within half a difficulty level of the staircase estimate.
+// Without graded answers the rating is returned unchanged.
+func (s *Staircase) Settle(r Rubric, rating int) int {
+	if s.Graded() == 0 {
+		return rating
+	}
+	estimate := s.Estimate(r)
+	band := int(math.Ceil(float64(r.Max-r.Min) / float64(len(difficultyLevels)) / 2))
+	return r.Clamp(clamp(estimate-band, rating, estimate+band))
+}
+
+// Note returns the per turn instruction for the model.
+func (s *Staircase) Note() string {
+	return fmt.Sprintf(adaptiveNote, difficultyLevels[s.Level])
+}
+
+// difficultyLevel returns the index of a difficulty name, -1 if unknown.
+func difficultyLevel(name string) int {
+	for i, level := range difficultyLevels {
+		if level == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+type staircaseAnswer struct{ level, grade int }
+
+func TestStaircaseRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     int
+		answers   []staircaseAnswer
+		wantLevel int
+		wantCount int
+	}{
+		{"good answer moves up", 1, []staircaseAnswer{{1, 5}}, 2, 1},
+		{"poor answer moves down", 1, []staircaseAnswer{{1, 1}}, 0, 1},
+		{"middling answer stays", 2, []staircaseAnswer{{2, 3}}, 2, 1},
+		{"top level stays on top", 3, []staircaseAnswer{{3, 5}}, 3, 1},
+		{"bottom level stays at the bottom", 0, []staircaseAnswer{{0, 1}}, 0, 1},
+		{"grades out of range are clamped", 1, []staircaseAnswer{{1, 9}}, 2, 1},
+		{"unknown level is not recorded", 1, []staircaseAnswer{{-1, 5}, {7, 5}}, 1, 0},
+		{"start is clamped", 9, nil, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStaircase(tt.start)
+			for _, a := range tt.answers {
+				s.Record(a.level, a.grade)
+			}
+			if s.Level != tt.wantLevel || s.Graded() != tt.wantCount {
+				t.Errorf("level %d with %d graded, want %d with %d", s.Level, s.Graded(), tt.wantLevel, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestStaircaseEstimate(t *testing.T) {
+	dreyfus := rubricPresets["dreyfus"]
+	tests := []struct {
+		name    string
+		answers []staircaseAnswer
+		want    int
+	}{
+		{"no answers", nil, 0},
+		{"wrong foundational answer", []staircaseAnswer{{0, 1}}, 1},
+		{"right foundational answer", []staircaseAnswer{{0, 5}}, 2},
+		{"right expert answer", []staircaseAnswer{{3, 5}}, 5},
+		{"only the later half counts", []staircaseAnswer{{0, 1}, {0, 1}, {2, 3}, {2, 3}}, 4},
+		{"odd count keeps the middle answer", []staircaseAnswer{{0, 1}, {1, 5}, {2, 1}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStaircase(0)
+			for _, a := range tt.answers {
+				s.Record(a.level, a.grade)
+			}
+			if got := s.Estimate(dreyfus); got != tt.want {
+				t.Errorf("Estimate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
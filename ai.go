@@ -8,36 +8,67 @@ import (
 	"os"
 	"profiler/env"
 	"strings"
-	"text/template"
+	"sync"
 
 	"google.golang.org/genai"
 )
 
 type (
-	NewCategoryMessage struct{}
+	// NewCategoryMessage starts the interview of a sub category.
+	NewCategoryMessage struct {
+		MainCategory string
+		SubCategory  string
+		Description  string
+		MainIndex    int
+		SubIndex     int
+		Position     int // 1-based position of the sub category among all sub categories
+		Total        int
+		Assessed     int // number of sub categories with a score
+		Questions    int // number of questions planned for the topic
+	}
 
 	AiMessage struct {
-		Content string
+		Content      string
+		QuestionType string   // one of questionTypes, "" for free text
+		Options      []string // options of choice questions
+		Asked        int      // number of questions asked in the topic so far, including this one
+		Challenge    bool     // an exercise follows in a ChallengeMessage instead of a question
 	}
 
 	AiThinkingMessage struct {
 		Thinking bool
 	}
+
+	// SelfAssessmentMessage asks the user to rate themself before the interview starts.
+	SelfAssessmentMessage struct {
+		Topic string
+		Min   int
+		Max   int
+	}
 )
 
 type AiMessageHistoryEntry struct {
-	Content string
-	IsUser  bool
+	Content    string `json:"content"`
+	IsUser     bool   `json:"is_user"`
+	Hidden     bool   `json:"hidden,omitempty"`     // instruction to the model that the user never saw
+	Difficulty string `json:"difficulty,omitempty"` // difficulty tag of a technical question
+	// QuestionType and Options describe choice questions of the model
+	QuestionType string       `json:"question_type,omitempty"`
+	Options      []string     `json:"options,omitempty"`
+	Grade        *AnswerGrade `json:"grade,omitempty"` // grade of an answer of the user
 }
 
-const modelName = "gemini-2.5-flash"
-
 var (
 	llmClient        *genai.Client
 	aiMessageHistory []AiMessageHistoryEntry
 )
 
-func init() {
+// InitLLM creates the client for the LLM backend. Only commands that talk to the model need it.
+func InitLLM() {
+	if env.GOOGLE_API_KEY == "" {
+		fmt.Fprintln(os.Stderr, "GOOGLE_API_KEY is not set")
+		os.Exit(1)
+	}
 	ctx := context.Background()
 	var err error
 	llmClient, err = genai.NewClient(ctx, &genai.ClientConfig{
@@ -51,181 +82,344 @@ func init() {
 	}
 }
 
-var (
-	prompt = template.Must(
-		template.New("prompt").
-			Parse(`You are interviewing a software developer to assess their expertise in {{.Topic}}.
-You will NOT use any generic greetings or chit-chat unrelated to {{.Topic}}.
-
-1. WARM-UP (Topic-Focused)
-   • Ask 1 open-ended question about a past project or role specifically
-     involving {{.Topic}}.
-     – Example: “Tell me about the most challenging {{.Topic}} project you’ve led.”
-   • One question at a time. Never bundle or repeat questions.
-   • If the candidate describes a project that involves {{.Topic}}:
-     – Say once: “Acknowledged: {{.Topic}} project noted.”
-     – Do NOT repeat that acknowledgement again.
-   • After that single acknowledgement, immediately transition to DIVE-IN.
-
-2. DIVE-IN
-   • Ask a targeted technical question on {{.Topic}} about the project they
-     just described.
-   • If the candidate answers “I don’t know,” “I have no idea,” or indicates
-     zero knowledge:
-     – Say “Understood. Moving to evaluation.”  
-     – Jump to step 3.
-   • If their answer is incorrect or incomplete:
-     – Give one-line feedback (e.g. “That’s not quite accurate; can you clarify X?”).  
-     – Ask a different follow-up on the same subtopic.
-   • After one follow-up without adequate progress, move to a new subtopic.
-   • Limit to 3 total technical questions (excluding the “I don’t know” exit).
-
-3. RATING & FEEDBACK
-   • Stop asking questions and assign an integer rating from 1–100:
-     – 100: Legend/mastery  
-     – 75: Highly proficient  
-     – 50: Competent mid-level  
-     – 25: Foundational knowledge with gaps  
-     – 10: Junior/basic theoretical  
-     – 1: No understanding/misconceptions  
-   • Do NOT change the initial baseline rating of 1—only increase it as you
-     gather evidence.
-   • Provide 1–2 sentences of actionable feedback on how to improve.
-
-4. CLARIFICATIONS
-   • If you don’t understand their wording, ask ONE short clarification, still
-     on {{.Topic}}.
-   • Never introduce off-topic pleasantries or multiple questions at once.
-
-Current topic: {{.Topic}}  
-Begin immediately with your first warm-up question.`))
-
-	config = &genai.GenerateContentConfig{
+// interviewConfig returns the generation config for an interview turn.
+// The model either asks the next question or gives the final rating on the configured rubric.
+// With ratingOnly set the model has to give the final rating.
+func interviewConfig(systemInstruction string, ratingOnly bool) *genai.GenerateContentConfig {
+	schema := &genai.Schema{
+		AnyOf: []*genai.Schema{messageSchema(), ratingSchema()},
+	}
+	if ratingOnly {
+		schema = ratingSchema()
+	}
+	return &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{
+			Parts: []*genai.Part{{Text: systemInstruction}},
+		},
 		ResponseMIMEType: "application/json",
-		ResponseSchema: &genai.Schema{
-			AnyOf: []*genai.Schema{
-				{
-					Type: genai.TypeObject,
-					Properties: map[string]*genai.Schema{
-						"message": {
-							Type:     genai.TypeString,
-							Nullable: genai.Ptr(false),
-						},
-					},
-					Required: []string{"message"},
-				},
-				{
-					Type: genai.TypeObject,
-					Properties: map[string]*genai.Schema{
-						"rating": {
-							Type:     genai.TypeInteger,
-							Nullable: genai.Ptr(false),
-							Minimum:  genai.Ptr(1.0),
-							Maximum:  genai.Ptr(100.0),
-						},
-						"comment": {
-							Type:     genai.TypeString,
-							Nullable: genai.Ptr(false),
-						},
-					},
-					Required: []string{"rating", "comment"},
-				},
+		ResponseSchema:   schema,
+	}
+}
+
+func messageSchema() *genai.Schema {
+	schema := &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"message": {
+				Type:     genai.TypeString,
+				Nullable: genai.Ptr(false),
 			},
 		},
+		Required: []string{"message"},
 	}
-)
+	schema.Properties["grade"] = gradeSchema()
+	schema.Properties["question_type"] = &genai.Schema{
+		Type:     genai.TypeString,
+		Nullable: genai.Ptr(true),
+		Enum:     questionTypes,
+	}
+	schema.Properties["options"] = &genai.Schema{
+		Type:     genai.TypeArray,
+		Nullable: genai.Ptr(true),
+		Items:    &genai.Schema{Type: genai.TypeString},
+	}
+	if challenge != nil && !challengeHandedOut {
+		schema.Properties["challenge"] = &genai.Schema{
+			Type:     genai.TypeBoolean,
+			Nullable: genai.Ptr(true),
+		}
+	}
+	if appConfig.Interview.Adaptive {
+		schema.Properties["difficulty"] = &genai.Schema{
+			Type:     genai.TypeString,
+			Nullable: genai.Ptr(true),
+			Enum:     difficultyLevels,
+		}
+	}
+	return schema
+}
+
+func ratingSchema() *genai.Schema {
+	schema := &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"rating": {
+				Type:     genai.TypeInteger,
+				Nullable: genai.Ptr(false),
+				Minimum:  genai.Ptr(float64(appConfig.Rubric.Min)),
+				Maximum:  genai.Ptr(float64(appConfig.Rubric.Max)),
+			},
+			"comment": {
+				Type:     genai.TypeString,
+				Nullable: genai.Ptr(false),
+			},
+			"confidence": {
+				Type:        genai.TypeNumber,
+				Description: "How sure you are about the rating, 0.0 to 1.0. Low if there was little evidence.",
+				Minimum:     genai.Ptr(0.0),
+				Maximum:     genai.Ptr(1.0),
+			},
+		},
+		Required: []string{"rating", "comment", "confidence"},
+	}
+	schema.Properties["grade"] = gradeSchema()
+	return schema
+}
 
 type AiResponse struct {
-	Message *string `json:"message"`
-	Rating  *int    `json:"rating"`
-	Comment *string `json:"comment"`
+	Message    *string      `json:"message"`
+	Grade      *AnswerGrade `json:"grade"`
+	Difficulty *string      `json:"difficulty"`
+	Rating     *int         `json:"rating"`
+	Comment    *string      `json:"comment"`
+	Confidence *float64     `json:"confidence"`
+	Challenge  *bool        `json:"challenge"`
+
+	QuestionType *string  `json:"question_type"`
+	Options      []string `json:"options"`
 }
 
+// promptHash is the hash of the prompt sources used for the current topic.
+var promptHash string
+
 func getPromptString() string {
+	tmpl, hash, err := getPromptTemplate(interviewPrompt, Categories[mainCategoryIndex], Categories[mainCategoryIndex].SubCategories[subCategoryIndex])
+	if err != nil {
+		Err(errors.Join(errors.New("failed to load prompt template"), err))
+		return ""
+	}
 	sb := strings.Builder{}
-	err := prompt.Execute(&sb, map[string]string{"Topic": GetCurrentCategory()})
+	err = tmpl.Execute(&sb, GetCurrentPromptData())
 	if err != nil {
 		Err(errors.Join(errors.New("failed to execute prompt template"), err))
 		return ""
 	}
+	promptHash = hash
 	return sb.String()
 }
 
+const (
+	firstQuestionRequest = "Ask your first question"
+	budgetExhaustedNote  = `
+
+The question budget for this topic is exhausted.
+Do NOT ask any further questions. Give your final rating and feedback now.`
+)
+
+// askedQuestions returns the number of messages the model sent for the current topic.
+func askedQuestions() int {
+	n := 0
+	for _, entry := range aiMessageHistory {
+		if !entry.IsUser {
+			n++
+		}
+	}
+	return n
+}
+
+// toContents converts the message history into the conversation sent to the model.
+func toContents(entries []AiMessageHistoryEntry) ([]*genai.Content, error) {
+	history := make([]*genai.Content, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Content == "" {
+			return nil, fmt.Errorf("empty content at index %d", i)
+		}
+		if entry.IsUser {
+			history = append(history, genai.NewContentFromText(entry.Content, "user"))
+		} else {
+			history = append(history, genai.NewContentFromText(entry.Content, "model"))
+		}
+	}
+	return history, nil
+}
+
+// lastModelEntry returns the latest message of the model in the history, nil if there is none.
+func lastModelEntry() *AiMessageHistoryEntry {
+	for i := len(aiMessageHistory) - 1; i >= 0; i-- {
+		if !aiMessageHistory[i].IsUser {
+			return &aiMessageHistory[i]
+		}
+	}
+	return nil
+}
+
+// engine serializes the steps of the interview, the frontends start each of them in a goroutine.
+// Without it a topic jump could move the current topic while another step still works on it.
+var engine sync.Mutex
+
+// turnLimit is the number of messages the model may send for the current topic, raised by disputes
+var turnLimit int
+
+// budgetExhausted reports whether the model used up its turns for the current topic.
+func budgetExhausted() bool {
+	return askedQuestions() >= turnLimit
+}
+
 func Begin() {
+	engine.Lock()
+	defer engine.Unlock()
 	if GetCurrentCategoryScore() > 0 && !RedoTakenTests {
-		ApplyRating(GetCurrentCategoryScore())
+		Advance()
 		return
 	}
-	aiMessageHistory = nil
-	teaProgram.Send(NewCategoryMessage{})
-	teaProgram.Send(AiThinkingMessage{Thinking: true})
-	ctx := context.Background()
+	begin()
+}
 
-	config.SystemInstruction = &genai.Content{
-		Parts: []*genai.Part{{Text: getPromptString()}},
+// JumpTo interviews the given sub category next, whether it was assessed before or not.
+// If the sub category cannot be interviewed the user is told and the current topic stays.
+func JumpTo(main, sub int) {
+	engine.Lock()
+	defer engine.Unlock()
+	mc := Categories[main]
+	sc := mc.SubCategories[sub]
+	if llmClient == nil {
+		// offline only topics with a question bank can be interviewed
+		bank, err := loadQuestionBank(mc, sc)
+		if err == nil && bank == nil {
+			err = errors.New("there is no question bank for it and no model to interview without one")
+		}
+		if err != nil {
+			program.Send(JumpFailedMessage{Topic: fmt.Sprintf("%s (%s)", sc.Name, mc.Name), Err: err})
+			return
+		}
 	}
+	mainCategoryIndex, subCategoryIndex = main, sub
+	begin()
+}
 
-	resp, err := llmClient.Models.GenerateContent(
-		ctx,
-		modelName,
-		genai.Text("Ask your first question"),
-		config,
-	)
-	if err != nil {
-		Err(errors.Join(errors.New("failed to generate content"), err))
+// begin starts the interview of the current sub category.
+func begin() {
+	aiMessageHistory = nil
+	turnLimit = appConfig.Interview.Turns()
+	staircase = nil
+	if appConfig.Interview.Adaptive {
+		staircase = newStaircase(startLevelForScore(GetCurrentCategoryScore()))
+	}
+	mc := Categories[mainCategoryIndex]
+	sc := mc.SubCategories[subCategoryIndex]
+	challenge = challengeFor(mc, sc)
+	challengeHandedOut = false
+	questionBank, offlineBank = nil, nil
+	if appConfig.QuestionBank.Mode != "" {
+		bank, err := loadQuestionBank(mc, sc)
+		if err != nil {
+			Err(errors.Join(errors.New("failed to load question bank"), err))
+			return
+		}
+		questionBank = bank
+		if bank != nil && appConfig.QuestionBank.Mode == questionBankOffline {
+			offlineBank = newBankSession(bank)
+		}
+	}
+	if offlineBank == nil && llmClient == nil {
+		// offline without a question bank for the topic
+		Advance()
 		return
 	}
-
-	aiResp := AiResponse{}
-	err = json.Unmarshal([]byte(resp.Text()), &aiResp)
-	if err != nil {
-		Err(errors.Join(errors.New("failed to unmarshal response"), err))
+	position, total, assessed := GetCurrentPosition()
+	questions := min(appConfig.Interview.WarmupQuestions+appConfig.Interview.MaxTechnicalQuestions, appConfig.Interview.Turns())
+	if offlineBank != nil {
+		questions = len(offlineBank.questions)
+	} else if questionBank != nil {
+		questions = len(questionBank.Draw()) + appConfig.Interview.WarmupQuestions
+		// the model has to ask every drawn question, with the same slack for the rating as Turns
+		turnLimit = max(turnLimit, questions+2)
+	}
+	program.Send(NewCategoryMessage{
+		MainCategory: mc.Name,
+		SubCategory:  sc.Name,
+		Description:  sc.Description,
+		MainIndex:    mainCategoryIndex,
+		SubIndex:     subCategoryIndex,
+		Position:     position,
+		Total:        total,
+		Assessed:     assessed,
+		Questions:    questions,
+	})
+	if appConfig.SelfAssessment {
+		program.Send(SelfAssessmentMessage{
+			Topic: GetCurrentCategory(),
+			Min:   appConfig.Rubric.Min,
+			Max:   appConfig.Rubric.Max,
+		})
 		return
 	}
+	start()
+}
 
-	teaProgram.Send(AiThinkingMessage{Thinking: false})
-
-	if aiResp.Message != nil {
-		teaProgram.Send(AiMessage{Content: *aiResp.Message})
+// SubmitSelfAssessment stores the self rating of the user and starts the interview.
+// A score of 0 skips the self assessment.
+func SubmitSelfAssessment(score int) {
+	engine.Lock()
+	defer engine.Unlock()
+	if score > 0 {
+		ApplySelfScore(score)
+		go SaveScores()
 	}
+	start()
+}
 
-	if aiResp.Comment != nil {
-		ApplyComment(*aiResp.Comment)
+// start asks the first question, from the question bank if the topic is interviewed offline.
+func start() {
+	if offlineBank != nil {
+		offlineBank.ask()
+		return
 	}
+	interview(firstQuestionRequest, true)
+}
 
-	if aiResp.Rating != nil {
-		ApplyRating(*aiResp.Rating)
+func Continue(userInput string) {
+	engine.Lock()
+	defer engine.Unlock()
+	if offlineBank != nil {
+		offlineBank.answer(userInput)
+		return
 	}
+	interview(userInput, false)
 }
 
-func Continue(userInput string) {
+// interview sends the user input together with the conversation so far to the model
+// and handles its answer. Once the question budget is exhausted a rating is forced.
+func interview(userInput string, hidden bool) {
 	ctx := context.Background()
-	teaProgram.Send(AiThinkingMessage{Thinking: true})
-
-	config.SystemInstruction = &genai.Content{
-		Parts: []*genai.Part{{Text: getPromptString()}},
+	program.Send(AiThinkingMessage{Thinking: true})
+
+	systemInstruction := getPromptString() + fmt.Sprintf(gradingNote, answerGradeMin, answerGradeMax) + questionTypesNote
+	ratingOnly := budgetExhausted()
+	if ratingOnly {
+		systemInstruction += budgetExhaustedNote
+	} else {
+		if staircase != nil {
+			systemInstruction += staircase.Note()
+		}
+		if challenge != nil && !challengeHandedOut {
+			systemInstruction += fmt.Sprintf(challengeNote, challenge.Title)
+		}
+		if questionBank != nil {
+			note, err := questionBank.Note()
+			if err != nil {
+				Err(err)
+				return
+			}
+			systemInstruction += note
+		}
 	}
+	config := interviewConfig(systemInstruction, ratingOnly)
 
 	aiMessageHistory = append(aiMessageHistory, AiMessageHistoryEntry{
 		Content: userInput,
 		IsUser:  true,
+		Hidden:  hidden,
 	})
-	history := make([]*genai.Content, 0, len(aiMessageHistory))
-	for _, entry := range aiMessageHistory {
-		if entry.Content == "" {
-			Err(fmt.Errorf("empty content at index %d", len(aiMessageHistory)-1))
-			return
-		}
-		if entry.IsUser {
-			history = append(history, genai.NewContentFromText(entry.Content, "user"))
-		} else {
-			history = append(history, genai.NewContentFromText(entry.Content, "model"))
-		}
+	history, err := toContents(aiMessageHistory)
+	if err != nil {
+		Err(err)
+		return
 	}
 
 	resp, err := llmClient.Models.GenerateContent(
 		ctx,
-		modelName,
+		appConfig.Model,
 		history,
 		config,
 	)
@@ -241,10 +435,43 @@ func Continue(userInput string) {
 		return
 	}
 
-	teaProgram.Send(AiThinkingMessage{Thinking: false})
+	program.Send(AiThinkingMessage{Thinking: false})
+
+	if aiResp.Grade != nil && !hidden {
+		// the grade belongs to the answer that was just sent
+		aiResp.Grade.normalize()
+		aiMessageHistory[len(aiMessageHistory)-1].Grade = aiResp.Grade
+		if question := lastModelEntry(); staircase != nil && question != nil && question.Difficulty != "" {
+			staircase.Record(difficultyLevel(question.Difficulty), aiResp.Grade.Correctness)
+		}
+	}
 
 	if aiResp.Message != nil {
-		teaProgram.Send(AiMessage{Content: *aiResp.Message})
+		entry := AiMessageHistoryEntry{
+			Content: *aiResp.Message,
+			IsUser:  false,
+		}
+		if aiResp.Difficulty != nil && difficultyLevel(*aiResp.Difficulty) >= 0 {
+			entry.Difficulty = *aiResp.Difficulty
+		}
+		if aiResp.QuestionType != nil && isChoiceQuestion(*aiResp.QuestionType, aiResp.Options) {
+			entry.QuestionType = *aiResp.QuestionType
+			entry.Options = aiResp.Options
+		}
+		aiMessageHistory = append(aiMessageHistory, entry)
+		handOut := aiResp.Challenge != nil && *aiResp.Challenge && challenge != nil && !challengeHandedOut
+		program.Send(AiMessage{
+			Content:      *aiResp.Message,
+			QuestionType: entry.QuestionType,
+			Options:      entry.Options,
+			Asked:        askedQuestions(),
+			Challenge:    handOut,
+		})
+
+		if handOut {
+			challengeHandedOut = true
+			program.Send(ChallengeMessage{Challenge: *challenge, Starter: starterCode(*challenge)})
+		}
 	}
 
 	if aiResp.Comment != nil {
@@ -252,6 +479,44 @@ func Continue(userInput string) {
 	}
 
 	if aiResp.Rating != nil {
-		ApplyRating(*aiResp.Rating)
+		rating := *aiResp.Rating
+		if staircase != nil {
+			rating = staircase.Settle(appConfig.Rubric, rating)
+		}
+		grades := gradesOf(aiMessageHistory)
+		confidence := ratingConfidence(aiResp.Confidence, grades)
+		ApplyConfidence(confidence, ratingUncertainty(appConfig.Rubric, confidence, len(grades)))
+		ApplyStamp(interviewPrompt.ID(), promptHash, appConfig.Model, appConfig.Rubric.Name)
+		ApplyPanel(nil, false)
+		ApplyAppeal(0, "")
+		if appConfig.Panel.Enabled() {
+			program.Send(AiThinkingMessage{Thinking: true})
+			panel, err := RunPanel(ctx, NewTranscript(rating), "")
+			program.Send(AiThinkingMessage{Thinking: false})
+			if err != nil {
+				Err(errors.Join(errors.New("failed to grade with panel"), err))
+				return
+			}
+			// the rating of the interviewer is one vote of the panel
+			panel = append([]PanelRating{{
+				Model:       appConfig.Model,
+				Rating:      appConfig.Rubric.Clamp(rating),
+				Comment:     Categories[mainCategoryIndex].SubCategories[subCategoryIndex].Comment,
+				Confidence:  confidence,
+				Interviewer: true,
+			}}, panel...)
+			var disagreement bool
+			rating, disagreement = Consensus(appConfig.Rubric, panel)
+			ApplyPanel(panel, disagreement)
+			// the feedback and the spread of the interviewer do not belong to the consensus
+			verdict := panelVerdict(rating, panel)
+			ApplyComment(verdict.Comment)
+			ApplyConfidence(verdict.Confidence, ratingUncertainty(appConfig.Rubric, verdict.Confidence, len(grades)))
+		}
+		if err := SaveTranscript(NewTranscript(rating)); err != nil {
+			Err(errors.Join(errors.New("failed to save transcript"), err))
+			return
+		}
+		ApplyRating(rating)
 	}
 }
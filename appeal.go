@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const assessmentKindAppeal = "appeal"
+
+const appealNote = `The candidate appeals the rating of %s they were given:
+“%s”
+
+Their argument:
+“%s”
+
+Reconsider the rating in the light of the transcript and the argument.
+Change it only if the argument shows that the transcript supports a different rating,
+and say in the feedback why you changed it or kept it.`
+
+// Appeal lets the model, or the grading panel if one is configured, reconsider the rating of the current topic
+// with the argument of the user. The original and the revised rating are recorded in the transcript as an assessment.
+func Appeal(argument string) {
+	engine.Lock()
+	defer engine.Unlock()
+	ctx := context.Background()
+	sc := Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	t, err := ReadTranscript(sc.Transcript)
+	if err != nil {
+		Err(errors.Join(errors.New("failed to read transcript"), err))
+		return
+	}
+
+	program.Send(AiThinkingMessage{Thinking: true})
+	extra := fmt.Sprintf(appealNote, appConfig.Rubric.Format(sc.Score), sc.Comment, argument)
+	var (
+		pr           PanelRating
+		panel        []PanelRating
+		disagreement bool
+	)
+	if appConfig.Panel.Enabled() {
+		panel, err = RunPanel(ctx, t, extra)
+		if err == nil {
+			var rating int
+			rating, disagreement = Consensus(appConfig.Rubric, panel)
+			pr = panelVerdict(rating, panel)
+		}
+	} else {
+		pr, err = gradeTranscript(ctx, appConfig.Model, t, extra)
+	}
+	program.Send(AiThinkingMessage{Thinking: false})
+	if err != nil {
+		Err(errors.Join(errors.New("failed to grade appeal"), err))
+		return
+	}
+
+	grades := gradesOf(t.Entries)
+	uncertainty := ratingUncertainty(appConfig.Rubric, pr.Confidence, len(grades))
+	t.Assessments = append(t.Assessments, Assessment{
+		Date:         time.Now(),
+		Kind:         assessmentKindAppeal,
+		Model:        pr.Model,
+		Prompt:       t.Prompt,
+		PromptHash:   t.PromptHash,
+		Rubric:       appConfig.Rubric,
+		Rating:       pr.Rating,
+		Comment:      pr.Comment,
+		Confidence:   pr.Confidence,
+		Uncertainty:  uncertainty,
+		Panel:        panel,
+		Disagreement: disagreement,
+		Original:     sc.Score,
+		Appeal:       argument,
+	})
+	if err := WriteTranscript(t); err != nil {
+		Err(errors.Join(errors.New("failed to save transcript"), err))
+		return
+	}
+
+	// the spread of the original rating does not belong to the revised one
+	ApplyAppeal(sc.Score, argument)
+	ApplyComment(pr.Comment)
+	ApplyConfidence(pr.Confidence, uncertainty)
+	ApplyPanel(panel, disagreement)
+	ApplyRating(pr.Rating)
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	questionBankOffline = "offline"
+	questionBankLLM     = "llm"
+
+	defaultBankQuestions = 5
+	// offlineModel is stamped on ratings that were graded without a model
+	offlineModel = "offline"
+)
+
+type (
+	// QuestionBank is a YAML file of prepared questions for one sub category.
+	QuestionBank struct {
+		Questions []BankQuestion `yaml:"questions"`
+
+		hash string
+	}
+
+	// BankQuestion is a prepared question with everything needed to grade the answer.
+	BankQuestion struct {
+		Question string `yaml:"question"`
+		// Type is one of questionTypes, free text if empty.
+		Type    string   `yaml:"type"`
+		Options []string `yaml:"options"`
+		// Answer lists the letters of the correct options, for ordering questions in the right order.
+		Answer []string `yaml:"answer"`
+		// Reference is the model answer of free text questions.
+		Reference string `yaml:"reference"`
+		// KeyPoints are graded by keyword for free text questions offline,
+		// alternatives of a key point are separated by "|".
+		KeyPoints  []string `yaml:"key_points"`
+		Difficulty string   `yaml:"difficulty"`
+	}
+
+	// bankSession is an interview from the question bank without a model.
+	bankSession struct {
+		bank      *QuestionBank
+		questions []BankQuestion
+		index     int
+		scores    []float64 // 0 to 1 per answered question
+		missed    []string  // key points and questions the candidate missed
+	}
+)
+
+const bankNote = `
+
+QUESTION BANK
+   • Ask exactly these prepared questions, in this order, one at a time,
+     instead of making up your own technical questions. Keep the wording.
+   • For choice and ordering questions set "question_type" and "options"
+     exactly as given.
+   • Grade every answer against the reference answer and key points.
+{{range $i, $q := .}}
+Question {{inc $i}}{{with $q.Difficulty}} ({{.}}){{end}}: {{$q.Question}}
+{{- if $q.Options}}
+  Type: {{$q.Type}}
+  Options: {{range $j, $o := $q.Options}}{{if $j}}; {{end}}{{$o}}{{end}}
+  Correct: {{join $q.Answer ", "}}
+{{- end}}
+{{- with $q.Reference}}
+  Reference answer: {{.}}
+{{- end}}
+{{- with $q.KeyPoints}}
+  Key points: {{join . "; "}}
+{{- end}}
+{{end}}`
+
+var (
+	// questionBank of the current topic, nil if there is none
+	questionBank *QuestionBank
+	// offlineBank is the session of the current topic, nil unless it is interviewed offline
+	offlineBank *bankSession
+)
+
+// getQuestionsDir returns the directory that holds the question banks.
+func getQuestionsDir() string {
+	return filepath.Join(getDataDir(), "questions")
+}
+
+// loadQuestionBank reads the question bank of a sub category from questions/<main>/<sub>.yaml.
+// It returns nil if there is no question bank for the topic.
+func loadQuestionBank(mc MainCategory, sc SubCategory) (*QuestionBank, error) {
+	name := filepath.Join(getQuestionsDir(), slug(mc.Name), slug(sc.Name)+".yaml")
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	bank := &QuestionBank{hash: hashPrompt(string(data))}
+	if err := yaml.Unmarshal(data, bank); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to parse %s", name), err)
+	}
+	for i, q := range bank.Questions {
+		if q.Type == "" {
+			bank.Questions[i].Type = questionFreeText
+		}
+		if err := bank.Questions[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s question %d: %w", name, i+1, err)
+		}
+	}
+	if len(bank.Questions) == 0 {
+		return nil, fmt.Errorf("%s has no questions", name)
+	}
+	return bank, nil
+}
+
+func (q BankQuestion) validate() error {
+	if q.Question == "" {
+		return errors.New("question is empty")
+	}
+	if !slices.Contains(questionTypes, q.Type) {
+		return fmt.Errorf("unknown type %q", q.Type)
+	}
+	if q.Difficulty != "" && difficultyLevel(q.Difficulty) < 0 {
+		return fmt.Errorf("unknown difficulty %q", q.Difficulty)
+	}
+	if q.Type == questionFreeText && len(q.KeyPoints) == 0 && appConfig.QuestionBank.Mode == questionBankOffline {
+		return errors.New("free text questions need key points to be graded offline")
+	}
+	if q.Type != questionFreeText {
+		if len(q.Options) < 2 {
+			return errors.New("choice questions need at least two options")
+		}
+		if len(q.Answer) == 0 {
+			return errors.New("choice questions need an answer")
+		}
+		for _, letter := range q.Answer {
+			if i := letterIndex(letter); i < 0 || i >= len(q.Options) {
+				return fmt.Errorf("answer %q is not an option", letter)
+			}
+		}
+	}
+	return nil
+}
+
+// Draw returns the questions of an interview: the first n in file order, so every candidate sees the same ones.
+func (b *QuestionBank) Draw() []BankQuestion {
+	n := appConfig.QuestionBank.Questions
+	if n <= 0 {
+		n = defaultBankQuestions
+	}
+	return b.Questions[:min(n, len(b.Questions))]
+}
+
+// Note returns the instruction for the model to interview from the question bank.
+func (b *QuestionBank) Note() (string, error) {
+	tmpl, err := template.New("bank").Funcs(template.FuncMap{
+		"inc":  func(i int) int { return i + 1 },
+		"join": strings.Join,
+	}).Parse(bankNote)
+	if err != nil {
+		return "", err
+	}
+	sb := strings.Builder{}
+	if err := tmpl.Execute(&sb, b.Draw()); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func letterIndex(letter string) int {
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+		return -1
+	}
+	return int(letter[0] - 'A')
+}
+
+// answerLetters matches the option lines of an answer from the choice list, e.g. "B) ..." or "2. C) ...".
+var answerLetters = regexp.MustCompile(`(?m)^\s*(?:\d+\.\s*)?([A-Z])\)`)
+
+// parseLetters returns the letters of the options the user picked, in the order they were given.
+func parseLetters(answer string) []string {
+	var letters []string
+	for _, match := range answerLetters.FindAllStringSubmatch(answer, -1) {
+		letters = append(letters, match[1])
+	}
+	return letters
+}
+
+// Grade scores an answer from 0 to 1 without a model and returns the key points that were missed.
+func (q BankQuestion) Grade(answer string) (float64, []string) {
+	switch q.Type {
+	case questionSingleChoice, questionOrdering:
+		if slices.Equal(parseLetters(answer), normalizeLetters(q.Answer)) {
+			return 1, nil
+		}
+		return 0, []string{q.Question}
+	case questionMultipleChoice:
+		// every option counts, picked correctly or correctly left out
+		picked := parseLetters(answer)
+		correct := normalizeLetters(q.Answer)
+		right := 0
+		for i := range q.Options {
+			letter := optionLetter(i)
+			if slices.Contains(picked, letter) == slices.Contains(correct, letter) {
+				right++
+			}
+		}
+		score := float64(right) / float64(len(q.Options))
+		if score < 1 {
+			return score, []string{q.Question}
+		}
+		return score, nil
+	default:
+		if len(q.KeyPoints) == 0 {
+			return 0, []string{q.Question}
+		}
+		lower := strings.ToLower(answer)
+		var missed []string
+		for _, point := range q.KeyPoints {
+			found := false
+			for _, alternative := range strings.Split(point, "|") {
+				if alternative = strings.ToLower(strings.TrimSpace(alternative)); alternative != "" && strings.Contains(lower, alternative) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missed = append(missed, point)
+			}
+		}
+		return 1 - float64(len(missed))/float64(len(q.KeyPoints)), missed
+	}
+}
+
+func normalizeLetters(letters []string) []string {
+	normalized := make([]string, len(letters))
+	for i, letter := range letters {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(letter))
+	}
+	return normalized
+}
+
+// weight lets harder questions count more for the rating.
+func (q BankQuestion) weight() float64 {
+	if level := difficultyLevel(q.Difficulty); level >= 0 {
+		return float64(level + 1)
+	}
+	return float64(len(difficultyLevels)+1) / 2
+}
+
+func newBankSession(bank *QuestionBank) *bankSession {
+	return &bankSession{bank: bank, questions: bank.Draw()}
+}
+
+// ask sends the next question of the offline interview.
+func (s *bankSession) ask() {
+	q := s.questions[s.index]
+	entry := AiMessageHistoryEntry{
+		Content:    q.Question,
+		Difficulty: q.Difficulty,
+	}
+	if isChoiceQuestion(q.Type, q.Options) {
+		entry.QuestionType = q.Type
+		entry.Options = q.Options
+	}
+	aiMessageHistory = append(aiMessageHistory, entry)
+	program.Send(AiMessage{
+		Content:      q.Question,
+		QuestionType: entry.QuestionType,
+		Options:      entry.Options,
+		Asked:        s.index + 1,
+	})
+}
+
+// answer grades the answer to the current question and asks the next one or rates the topic.
+func (s *bankSession) answer(userInput string) {
+	q := s.questions[s.index]
+	score, missed := q.Grade(userInput)
+	s.scores = append(s.scores, score)
+	s.missed = append(s.missed, missed...)
+
+	confidence := 1.0
+	if q.Type == questionFreeText {
+		// keyword matching misses paraphrases
+		confidence = 0.6
+	}
+	aiMessageHistory = append(aiMessageHistory, AiMessageHistoryEntry{
+		Content: userInput,
+		IsUser:  true,
+		Grade: &AnswerGrade{
+			Correctness: answerGradeMin + int(math.Round(score*float64(answerGradeMax-answerGradeMin))),
+			Depth:       answerGradeMin + int(math.Round(score*float64(answerGradeMax-answerGradeMin))),
+			Confidence:  confidence,
+		},
+	})
+
+	s.index++
+	if s.index < len(s.questions) {
+		s.ask()
+		return
+	}
+	s.rate()
+}
+
+// rate computes the rating from the difficulty weighted scores of all answers.
+func (s *bankSession) rate() {
+	var sum, weights float64
+	for i, q := range s.questions {
+		sum += s.scores[i] * q.weight()
+		weights += q.weight()
+	}
+	r := appConfig.Rubric
+	rating := r.Clamp(r.Min + int(math.Round(sum/weights*float64(r.Max-r.Min))))
+
+	comment := fmt.Sprintf("Scored %.0f%% on %d prepared questions.", sum/weights*100, len(s.questions))
+	if len(s.missed) > 0 {
+		comment += " Review: " + strings.Join(s.missed, "; ") + "."
+	}
+
+	grades := gradesOf(aiMessageHistory)
+	confidence := ratingConfidence(nil, grades)
+	ApplyComment(comment)
+	ApplyConfidence(confidence, ratingUncertainty(r, confidence, len(grades)))
+	ApplyStamp("question-bank", s.bank.hash, offlineModel, r.Name)
+	ApplyPanel(nil, false)
+	ApplyAppeal(0, "")
+	if err := SaveTranscript(NewTranscript(rating)); err != nil {
+		Err(errors.Join(errors.New("failed to save transcript"), err))
+		return
+	}
+	ApplyRating(rating)
+}
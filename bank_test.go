@@ -0,0 +1,134 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLetters(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		want   []string
+	}{
+		{"single", "My answer:\nB) a heap", []string{"B"}},
+		{"multiple", "My answer:\nA) first\nC) third", []string{"A", "C"}},
+		{"ordering", "My order:\n1. C) third\n2. A) first\n3. B) second", []string{"C", "A", "B"}},
+		{"indented", "  D) fourth", []string{"D"}},
+		{"free text", "I would use a heap because B) is wrong", nil},
+		{"lower case", "a) first", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLetters(tt.answer); !slices.Equal(got, tt.want) {
+				t.Errorf("parseLetters(%q) = %q, want %q", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLetterIndex(t *testing.T) {
+	tests := []struct {
+		letter string
+		want   int
+	}{
+		{"A", 0},
+		{"c", 2},
+		{" Z ", 25},
+		{"", -1},
+		{"AB", -1},
+		{"1", -1},
+	}
+	for _, tt := range tests {
+		if got := letterIndex(tt.letter); got != tt.want {
+			t.Errorf("letterIndex(%q) = %d, want %d", tt.letter, got, tt.want)
+		}
+	}
+}
+
+func TestBankQuestionGrade(t *testing.T) {
+	options := []string{"first", "second", "third", "fourth"}
+	tests := []struct {
+		name       string
+		question   BankQuestion
+		answer     string
+		want       float64
+		wantMissed []string
+	}{
+		{
+			name:     "single choice right",
+			question: BankQuestion{Question: "q", Type: questionSingleChoice, Options: options, Answer: []string{"b"}},
+			answer:   "My answer:\nB) second",
+			want:     1,
+		},
+		{
+			name:       "single choice wrong",
+			question:   BankQuestion{Question: "q", Type: questionSingleChoice, Options: options, Answer: []string{"B"}},
+			answer:     "My answer:\nA) first",
+			want:       0,
+			wantMissed: []string{"q"},
+		},
+		{
+			name:     "ordering right",
+			question: BankQuestion{Question: "q", Type: questionOrdering, Options: options[:3], Answer: []string{"C", "A", "B"}},
+			answer:   "My order:\n1. C) third\n2. A) first\n3. B) second",
+			want:     1,
+		},
+		{
+			name:       "ordering swapped",
+			question:   BankQuestion{Question: "q", Type: questionOrdering, Options: options[:3], Answer: []string{"C", "A", "B"}},
+			answer:     "My order:\n1. A) first\n2. C) third\n3. B) second",
+			want:       0,
+			wantMissed: []string{"q"},
+		},
+		{
+			name:     "multiple choice right",
+			question: BankQuestion{Question: "q", Type: questionMultipleChoice, Options: options, Answer: []string{"A", "C"}},
+			answer:   "My answer:\nA) first\nC) third",
+			want:     1,
+		},
+		{
+			// A and D are right, C is missing and B is correctly left out
+			name:       "multiple choice partly right",
+			question:   BankQuestion{Question: "q", Type: questionMultipleChoice, Options: options, Answer: []string{"A", "C"}},
+			answer:     "My answer:\nA) first",
+			want:       0.75,
+			wantMissed: []string{"q"},
+		},
+		{
+			name:       "multiple choice nothing picked",
+			question:   BankQuestion{Question: "q", Type: questionMultipleChoice, Options: options, Answer: []string{"A", "B", "C", "D"}},
+			answer:     "no idea",
+			want:       0,
+			wantMissed: []string{"q"},
+		},
+		{
+			name:     "free text all key points",
+			question: BankQuestion{Question: "q", KeyPoints: []string{"heap", "O(log n)|logarithmic"}},
+			answer:   "A binary Heap, inserting is logarithmic.",
+			want:     1,
+		},
+		{
+			name:       "free text missed key point",
+			question:   BankQuestion{Question: "q", Type: questionFreeText, KeyPoints: []string{"heap", "O(log n)|logarithmic"}},
+			answer:     "a heap",
+			want:       0.5,
+			wantMissed: []string{"O(log n)|logarithmic"},
+		},
+		{
+			name:       "free text without key points",
+			question:   BankQuestion{Question: "q", Reference: "a heap"},
+			answer:     "a heap",
+			want:       0,
+			wantMissed: []string{"q"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missed := tt.question.Grade(tt.answer)
+			if got != tt.want || !slices.Equal(missed, tt.wantMissed) {
+				t.Errorf("Grade(%q) = %v, %q, want %v, %q", tt.answer, got, missed, tt.want, tt.wantMissed)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// snippetContext is the number of characters shown around a search match.
+const snippetContext = 20
+
+// browser lists the stored transcripts, searches them and shows one read-only.
+type browser struct {
+	transcripts []Transcript // newest first
+	texts       []string     // lower case text of each transcript for the search
+	matches     []int        // indices of the transcripts that match the search
+	search      textinput.Model
+	searching   bool
+	cursor      int // index in matches
+	offset      int // first visible match
+	open        bool
+	viewport    viewport.Model
+	width       int
+	height      int
+	// standalone quits the program when the browser is closed, otherwise it returns to the interview
+	standalone bool
+	closed     bool
+}
+
+func newBrowser(width, height int) (browser, error) {
+	transcripts, err := ListTranscripts()
+	if err != nil {
+		return browser{}, err
+	}
+	slices.Reverse(transcripts)
+
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search questions and answers"
+	search.PlaceholderStyle = lipgloss.NewStyle().Foreground(theme.Placeholder)
+
+	b := browser{
+		transcripts: transcripts,
+		search:      search,
+		viewport:    viewport.New(width, height),
+	}
+	for _, t := range transcripts {
+		b.texts = append(b.texts, strings.ToLower(t.Topic()+"\n"+t.Comment+"\n"+t.Conversation()))
+	}
+	b.resize(width, height)
+	b.filter()
+	return b, nil
+}
+
+// listHeight returns the number of lines for the list of transcripts.
+func (b *browser) listHeight() int {
+	return max(b.height-4, 1) // title, search and help
+}
+
+func (b *browser) resize(width, height int) {
+	b.width, b.height = width, height
+	b.search.Width = max(width-4, 10)
+	b.viewport.Width = width
+	b.viewport.Height = max(height-3, 1)
+	if b.open {
+		b.render()
+	}
+}
+
+// filter keeps the transcripts that contain every word of the search.
+func (b *browser) filter() {
+	words := strings.Fields(strings.ToLower(b.search.Value()))
+	b.matches = b.matches[:0]
+	for i, text := range b.texts {
+		if !slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(text, w) }) {
+			b.matches = append(b.matches, i)
+		}
+	}
+	b.cursor = min(b.cursor, max(len(b.matches)-1, 0))
+	b.scroll()
+}
+
+// scroll keeps the cursor within the visible lines of the list.
+func (b *browser) scroll() {
+	lines := b.listHeight()
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+lines {
+		b.offset = b.cursor - lines + 1
+	}
+	b.offset = max(min(b.offset, len(b.matches)-lines), 0)
+}
+
+// snippet returns the text around the first word of the search in a transcript.
+func (b *browser) snippet(i int) string {
+	words := strings.Fields(strings.ToLower(b.search.Value()))
+	if len(words) == 0 {
+		return ""
+	}
+	text := b.texts[i]
+	pos := strings.Index(text, words[0])
+	if pos < 0 {
+		return ""
+	}
+	start := max(pos-snippetContext, 0)
+	end := min(pos+len(words[0])+snippetContext, len(text))
+	// keep whole runes at the cut
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	return "…" + strings.Join(strings.Fields(text[start:end]), " ") + "…"
+}
+
+// render shows the selected transcript in the viewport.
+func (b *browser) render() {
+	t := b.transcripts[b.matches[b.cursor]]
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStylePath(theme.Markdown),
+		glamour.WithWordWrap(max(b.width-4, 20)),
+	)
+	content := t.Markdown()
+	if err == nil {
+		if rendered, err := renderer.Render(content); err == nil {
+			content = rendered
+		}
+	}
+	b.viewport.SetContent(content)
+}
+
+func (b browser) Init() tea.Cmd {
+	return nil
+}
+
+func (b browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.resize(msg.Width, msg.Height)
+
+	case tea.MouseMsg:
+		if b.open {
+			var cmd tea.Cmd
+			b.viewport, cmd = b.viewport.Update(msg)
+			return b, cmd
+		}
+
+	case tea.KeyMsg:
+		if key.Matches(msg, keys.Quit) {
+			return b, tea.Quit
+		}
+		switch {
+		case b.open:
+			if key.Matches(msg, keys.Close) {
+				b.open = false
+				return b, nil
+			}
+			var cmd tea.Cmd
+			b.viewport, cmd = b.viewport.Update(msg)
+			return b, cmd
+
+		case b.searching:
+			switch msg.Type {
+			case tea.KeyEnter, tea.KeyEsc:
+				b.searching = false
+				b.search.Blur()
+				return b, nil
+			}
+			var cmd tea.Cmd
+			b.search, cmd = b.search.Update(msg)
+			b.filter()
+			return b, cmd
+
+		case key.Matches(msg, keys.Search):
+			b.searching = true
+			return b, b.search.Focus()
+
+		case key.Matches(msg, keys.Up):
+			b.cursor = max(b.cursor-1, 0)
+			b.scroll()
+
+		case key.Matches(msg, keys.Down):
+			b.cursor = min(b.cursor+1, max(len(b.matches)-1, 0))
+			b.scroll()
+
+		case key.Matches(msg, keys.Select):
+			if len(b.matches) > 0 {
+				b.open = true
+				b.render()
+				b.viewport.GotoTop()
+			}
+
+		case key.Matches(msg, keys.Close, keys.Transcripts):
+			b.closed = true
+			if b.standalone {
+				return b, tea.Quit
+			}
+		}
+	}
+	return b, nil
+}
+
+func (b browser) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	helpStyle := dimStyle.MarginTop(1)
+
+	if b.open {
+		t := b.transcripts[b.matches[b.cursor]]
+		title := lipgloss.NewStyle().Bold(true).MaxWidth(b.width).Render(t.Topic() + " · " + t.Date.Format("2006-01-02 15:04"))
+		help := helpView(keys.Up, keys.Down, keys.Close, keys.Quit, keys.Scroll)
+		return fmt.Sprintf("%s\n%s\n%s", title, b.viewport.View(), helpStyle.MaxWidth(b.width).Render(help))
+	}
+
+	title := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Transcripts (%d of %d)", len(b.matches), len(b.transcripts)))
+	searchView := dimStyle.Render(b.search.View())
+	if b.searching {
+		searchView = b.search.View()
+	}
+
+	var lines []string
+	if len(b.transcripts) == 0 {
+		lines = append(lines, dimStyle.Render("No transcripts yet, they are stored after every rated topic."))
+	}
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+	for i := b.offset; i < min(b.offset+b.listHeight(), len(b.matches)); i++ {
+		t := b.transcripts[b.matches[i]]
+		line := fmt.Sprintf("%s  %-14s  %s", t.Date.Format("2006-01-02 15:04"), t.Rubric.FormatUncertain(t.Rating, t.Uncertainty), t.Topic())
+		if snippet := b.snippet(b.matches[i]); snippet != "" {
+			line += "  " + dimStyle.Render(snippet)
+		}
+		line = lipgloss.NewStyle().MaxWidth(b.width).Render(line)
+		if i == b.cursor {
+			line = cursorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	list := lipgloss.NewStyle().Height(b.listHeight()).Render(strings.Join(lines, "\n"))
+
+	open := keys.Select
+	open.SetHelp(open.Help().Key, "open")
+	help := helpView(keys.Up, keys.Down, open, keys.Search, either(keys.Close, keys.Transcripts), keys.Quit)
+	if b.searching {
+		help = helpView(helpOnly("Enter/Esc", "done searching"))
+	}
+	return fmt.Sprintf("%s\n%s\n%s\n%s", title, searchView, list, helpStyle.MaxWidth(b.width).Render(help))
+}
+
+// Transcripts opens the transcript browser without starting an interview.
+func Transcripts() error {
+	b, err := newBrowser(80, 24)
+	if err != nil {
+		return errors.Join(errors.New("failed to list transcripts"), err)
+	}
+	b.standalone = true
+	_, err = tea.NewProgram(b, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	return err
+}
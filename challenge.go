@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultChallengeTimeout = 60 // seconds
+	// challengeOutputLimit keeps long test output from flooding the conversation
+	challengeOutputLimit = 4000
+	// challengeWaitDelay is how long the output is read after the command exited or was killed,
+	// processes it started may keep the output open
+	challengeWaitDelay = 2 * time.Second
+)
+
+const challengeNote = `
+
+HANDS-ON EXERCISE
+   • A practical exercise is available for this topic: “%s”.
+   • After the warm-up, hand it out once by introducing it in one or two
+     sentences and setting "challenge" to true. Do not write the solution.
+   • The candidate's code and the results of the test cases will be sent to you
+     as their next answer. Take both into account for your rating.`
+
+type (
+	// ChallengeMessage hands out a coding exercise to the user.
+	ChallengeMessage struct {
+		Challenge ChallengeConfig
+		Starter   string // starter code the solution file is prefilled with
+	}
+
+	// ChallengeResultMessage shows the submitted solution and its test results.
+	ChallengeResultMessage struct {
+		Content string
+	}
+)
+
+var (
+	// challenge is the exercise of the current topic, nil if there is none
+	challenge *ChallengeConfig
+	// challengeHandedOut is set once the model handed out the exercise of the current topic
+	challengeHandedOut bool
+)
+
+// getChallengesDir returns the directory that holds the test cases of the exercises.
+func getChallengesDir() string {
+	return filepath.Join(getDataDir(), "challenges")
+}
+
+// challengeFor returns the configured exercise of a sub category, nil if there is none.
+func challengeFor(mc MainCategory, sc SubCategory) *ChallengeConfig {
+	for i, c := range appConfig.Challenges {
+		main, sub, ok := strings.Cut(c.Topic, "/")
+		if !ok {
+			main, sub = "", main
+		}
+		if slug(sub) == slug(sc.Name) && (main == "" || slug(main) == slug(mc.Name)) {
+			return &appConfig.Challenges[i]
+		}
+	}
+	return nil
+}
+
+// starterCode returns the content of the solution file in the exercise directory, if there is one.
+func starterCode(c ChallengeConfig) string {
+	data, err := os.ReadFile(filepath.Join(getChallengesDir(), c.Dir, c.File))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// copyDir copies the files of src into dst.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
+
+// runChallenge writes the solution next to the test cases in a temporary directory and runs the test command.
+func runChallenge(c ChallengeConfig, code string) (bool, string, error) {
+	dir, err := os.MkdirTemp("", "profiler-challenge-")
+	if err != nil {
+		return false, "", err
+	}
+	defer os.RemoveAll(dir)
+
+	if c.Dir != "" {
+		if err := copyDir(filepath.Join(getChallengesDir(), c.Dir), dir); err != nil {
+			return false, "", errors.Join(errors.New("failed to copy test cases"), err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, c.File), []byte(code), 0644); err != nil {
+		return false, "", err
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultChallengeTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...)
+	cmd.Dir = dir
+	cmd.WaitDelay = challengeWaitDelay
+	killProcessGroup(cmd)
+	out, err := cmd.CombinedOutput()
+	output := string(out)
+	if len(output) > challengeOutputLimit {
+		output = output[:challengeOutputLimit] + "\n[output truncated]"
+	}
+	if ctx.Err() != nil {
+		return false, output + fmt.Sprintf("\n[timed out after %d seconds]", timeout), nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, output, nil
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// the command passed but left a process behind that kept the output open
+		return true, output, nil
+	}
+	if err != nil {
+		return false, "", errors.Join(errors.New("failed to run test command"), err)
+	}
+	return true, output, nil
+}
+
+// SubmitChallenge runs the test cases against the solution of the user
+// and sends code and results to the model as the next answer.
+func SubmitChallenge(code string) {
+	engine.Lock()
+	defer engine.Unlock()
+	if challenge == nil {
+		return
+	}
+	program.Send(AiThinkingMessage{Thinking: true})
+	passed, output, err := runChallenge(*challenge, code)
+
+	result := "FAILED"
+	if passed {
+		result = "PASSED"
+	}
+	if err != nil {
+		// a broken exercise setup is not the fault of the user, the model is told the tests did not run
+		result = "NOT RUN"
+		output = err.Error()
+	}
+	lang := strings.TrimPrefix(filepath.Ext(challenge.File), ".")
+	content := fmt.Sprintf(
+		"My solution for “%s”:\n\n```%s\n%s\n```\n\nTest result: **%s**\n\n```\n%s\n```",
+		challenge.Title, lang, strings.TrimSpace(code), result, strings.TrimSpace(output),
+	)
+	program.Send(ChallengeResultMessage{Content: content})
+	interview(content, false)
+}
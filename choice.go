@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	questionFreeText       = "free_text"
+	questionSingleChoice   = "single_choice"
+	questionMultipleChoice = "multiple_choice"
+	questionOrdering       = "ordering"
+)
+
+var questionTypes = []string{questionFreeText, questionSingleChoice, questionMultipleChoice, questionOrdering}
+
+const questionTypesNote = `
+
+QUESTION TYPES
+   • Besides free text questions ("question_type": "free_text") you may ask
+     objective questions, they are faster to answer and easier to grade:
+     – "single_choice": exactly one of "options" is correct
+     – "multiple_choice": any number of "options" are correct
+     – "ordering": the candidate puts "options" into the right order,
+       list them shuffled
+   • Use 3–6 short options without letters or numbers, they are added for you.
+   • Ask at least one free text question per topic.`
+
+// choice is the state of a choice question the user answers in the TUI.
+type choice struct {
+	questionType string
+	options      []string
+	order        []int // ordering questions: current order of the options
+	selected     []bool
+	cursor       int
+}
+
+func newChoice(questionType string, options []string) *choice {
+	c := &choice{
+		questionType: questionType,
+		options:      options,
+		selected:     make([]bool, len(options)),
+	}
+	for i := range options {
+		c.order = append(c.order, i)
+	}
+	return c
+}
+
+// isChoiceQuestion reports whether a question is answered from a list instead of the textarea.
+func isChoiceQuestion(questionType string, options []string) bool {
+	return questionType != "" && questionType != questionFreeText && len(options) > 1 && slices.Contains(questionTypes, questionType)
+}
+
+func optionLetter(i int) string {
+	return string(rune('A' + i))
+}
+
+func (c *choice) up() {
+	c.cursor = max(c.cursor-1, 0)
+}
+
+func (c *choice) down() {
+	c.cursor = min(c.cursor+1, len(c.options)-1)
+}
+
+// toggle selects the option under the cursor, single choice questions keep only one selection.
+func (c *choice) toggle() {
+	switch c.questionType {
+	case questionSingleChoice:
+		for i := range c.selected {
+			c.selected[i] = i == c.cursor
+		}
+	case questionMultipleChoice:
+		c.selected[c.cursor] = !c.selected[c.cursor]
+	}
+}
+
+// move shifts the option under the cursor of an ordering question by delta positions.
+func (c *choice) move(delta int) {
+	if c.questionType != questionOrdering {
+		return
+	}
+	target := c.cursor + delta
+	if target < 0 || target >= len(c.order) {
+		return
+	}
+	c.order[c.cursor], c.order[target] = c.order[target], c.order[c.cursor]
+	c.cursor = target
+}
+
+// answer returns the answer as text for the model, "" if nothing is selected yet.
+func (c *choice) answer() string {
+	var parts []string
+	switch c.questionType {
+	case questionOrdering:
+		for pos, i := range c.order {
+			parts = append(parts, fmt.Sprintf("%d. %s) %s", pos+1, optionLetter(i), c.options[i]))
+		}
+		return "My order:\n" + strings.Join(parts, "\n")
+	default:
+		for i, selected := range c.selected {
+			if selected {
+				parts = append(parts, fmt.Sprintf("%s) %s", optionLetter(i), c.options[i]))
+			}
+		}
+		if len(parts) == 0 {
+			return ""
+		}
+		return "My answer:\n" + strings.Join(parts, "\n")
+	}
+}
+
+// parse selects the options from typed letters, e.g. "B" or "A, C", and returns the answer.
+// It reports false if the input is not a valid selection, ordering questions need every letter once.
+func (c *choice) parse(input string) (string, bool) {
+	fields := strings.FieldsFunc(strings.ToUpper(input), func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	})
+	var picked []int
+	for _, field := range fields {
+		i := letterIndex(field)
+		if i < 0 || i >= len(c.options) || slices.Contains(picked, i) {
+			return "", false
+		}
+		picked = append(picked, i)
+	}
+	switch {
+	case c.questionType == questionOrdering && len(picked) != len(c.options),
+		c.questionType == questionSingleChoice && len(picked) != 1,
+		len(picked) == 0:
+		return "", false
+	}
+	if c.questionType == questionOrdering {
+		c.order = picked
+	} else {
+		for _, i := range picked {
+			c.selected[i] = true
+		}
+	}
+	return c.answer(), true
+}
+
+// height returns the number of lines of the list.
+func (c *choice) height() int {
+	return len(c.options)
+}
+
+func (c *choice) view(width int) string {
+	cursorStyle := lipgloss.NewStyle().Bold(true)
+	lines := make([]string, 0, len(c.options))
+	for pos := range c.options {
+		var line string
+		switch c.questionType {
+		case questionOrdering:
+			i := c.order[pos]
+			line = fmt.Sprintf("%d. %s) %s", pos+1, optionLetter(i), c.options[i])
+		case questionSingleChoice:
+			mark := "( )"
+			if c.selected[pos] {
+				mark = "(•)"
+			}
+			line = fmt.Sprintf("%s %s) %s", mark, optionLetter(pos), c.options[pos])
+		default:
+			mark := "[ ]"
+			if c.selected[pos] {
+				mark = "[x]"
+			}
+			line = fmt.Sprintf("%s %s) %s", mark, optionLetter(pos), c.options[pos])
+		}
+		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
+		if pos == c.cursor {
+			line = cursorStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// help returns the key help for the question type.
+func (c *choice) help() string {
+	if c.questionType == questionOrdering {
+		return helpView(keys.Up, keys.Down, keys.MoveUp, keys.MoveDown, keys.Send, keys.TextAnswer, keys.Quit)
+	}
+	return helpView(keys.Up, keys.Down, keys.Toggle, keys.Send, keys.TextAnswer, keys.Quit)
+}
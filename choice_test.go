@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestChoiceParse(t *testing.T) {
+	options := []string{"first", "second", "third"}
+	tests := []struct {
+		name         string
+		questionType string
+		input        string
+		want         string
+		wantOK       bool
+	}{
+		{"single", questionSingleChoice, "b", "My answer:\nB) second", true},
+		{"single with two letters", questionSingleChoice, "A B", "", false},
+		{"multiple", questionMultipleChoice, "C, a", "My answer:\nA) first\nC) third", true},
+		{"multiple with semicolons", questionMultipleChoice, "a;b", "My answer:\nA) first\nB) second", true},
+		{"ordering", questionOrdering, "C A B", "My order:\n1. C) third\n2. A) first\n3. B) second", true},
+		{"ordering missing a letter", questionOrdering, "C A", "", false},
+		{"duplicate letter", questionMultipleChoice, "A A", "", false},
+		{"letter out of range", questionSingleChoice, "D", "", false},
+		{"words", questionSingleChoice, "a heap", "", false},
+		{"empty", questionMultipleChoice, " ", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newChoice(tt.questionType, options).parse(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parse(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/x/term"
+)
+
+// copyToClipboard copies text to the clipboard of the terminal with an OSC52 escape sequence,
+// which also works over SSH. Terminals without OSC52 support ignore it.
+func copyToClipboard(text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case os.Getenv("STY") != "":
+		seq = seq.Screen()
+	}
+	// stderr is the terminal as well but not written by the renderer, unless it is redirected
+	if term.IsTerminal(os.Stderr.Fd()) {
+		_, err := seq.WriteTo(os.Stderr)
+		return err
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return errors.New("no terminal to copy through")
+	}
+	defer tty.Close()
+	_, err = seq.WriteTo(tty)
+	return err
+}
+
+// getExportsDir returns the directory conversations are exported to.
+func getExportsDir() string {
+	return filepath.Join(getDataDir(), "exports")
+}
+
+// exportConversation writes the conversation shown in the TUI to a Markdown file and returns its name.
+func exportConversation(topic NewCategoryMessage, messages []Message) (string, error) {
+	now := time.Now()
+	sb := strings.Builder{}
+	if topic.SubCategory != "" {
+		fmt.Fprintf(&sb, "# %s (%s)\n\n", topic.SubCategory, topic.MainCategory)
+	}
+	fmt.Fprintf(&sb, "%s\n\n", now.Format("2006-01-02 15:04"))
+	for _, msg := range messages {
+		if msg.IsUser {
+			sb.WriteString("**You:**\n\n")
+		} else {
+			sb.WriteString("**Interviewer:**\n\n")
+		}
+		sb.WriteString(strings.TrimSpace(msg.Content) + "\n\n")
+	}
+
+	name := filepath.Join(getExportsDir(), now.Format("20060102-150405")+"-"+slug(topic.MainCategory)+"-"+slug(topic.SubCategory)+".md")
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return "", err
+	}
+	return name, os.WriteFile(name, []byte(sb.String()), 0644)
+}
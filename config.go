@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultModelName  = "gemini-2.5-flash"
+	defaultPromptName = "interview"
+)
+
+// Config is read from config.json next to the data file. Missing fields keep their defaults.
+type Config struct {
+	// Model is the model used for interviews and learning plans.
+	Model string `json:"model"`
+	// Prompt selects the interview prompt from the prompt library,
+	// either "name" for the latest version or "name@version" for a fixed one.
+	Prompt string `json:"prompt"`
+	// Rubric is the rating scale, either a preset name ("default", "dreyfus") or a custom rubric.
+	Rubric Rubric `json:"rubric"`
+	// Interview limits the length of the interview per topic.
+	Interview InterviewConfig `json:"interview"`
+	// SelfAssessment asks for a self rating before every interview.
+	SelfAssessment bool `json:"self_assessment"`
+	// Challenges are hands-on exercises the interviewer can hand out for a topic.
+	Challenges []ChallengeConfig `json:"challenges"`
+	// Panel lets additional models grade every transcript independently.
+	Panel PanelConfig `json:"panel"`
+	// QuestionBank interviews topics with a question bank from prepared questions.
+	QuestionBank QuestionBankConfig `json:"question_bank"`
+	// Keys binds actions of the interview TUI to other keys, e.g. {"send": ["enter"], "new_line": ["alt+enter"]}.
+	Keys map[string][]string `json:"keys"`
+	// Theme is "auto", "dark", "light", "high-contrast" or the path of a theme file relative to the data directory.
+	Theme string `json:"theme"`
+}
+
+type InterviewConfig struct {
+	// WarmupQuestions is the number of open questions about past projects, may be 0.
+	WarmupQuestions int `json:"warmup_questions"`
+	// MaxTechnicalQuestions is the number of technical questions the model is asked to stay within.
+	MaxTechnicalQuestions int `json:"max_technical_questions"`
+	// MaxTurns is the hard limit of messages the model may send per topic before it has to rate.
+	// 0 allows the warm-up and technical questions plus two clarifications.
+	MaxTurns int `json:"max_turns"`
+	// Adaptive lets the answer grades move the difficulty of the next question up or down.
+	Adaptive bool `json:"adaptive"`
+}
+
+// Turns returns the number of messages the model may send per topic before a rating is forced.
+func (ic InterviewConfig) Turns() int {
+	if ic.MaxTurns > 0 {
+		return ic.MaxTurns
+	}
+	return ic.WarmupQuestions + ic.MaxTechnicalQuestions + 2
+}
+
+type ChallengeConfig struct {
+	// Topic is "Main category/Sub category" or just the sub category name.
+	Topic string `json:"topic"`
+	Title string `json:"title"`
+	// Task is the description of the exercise shown to the user, Markdown.
+	Task string `json:"task"`
+	// Dir holds the test cases and optional starter code, relative to the challenges directory.
+	Dir string `json:"dir"`
+	// File is the name of the solution file, e.g. "solution.go".
+	File string `json:"file"`
+	// Command runs the test cases in a copy of Dir, e.g. ["go", "test", "./..."].
+	Command []string `json:"command"`
+	// Timeout of the command in seconds, 60 if not set.
+	Timeout int `json:"timeout"`
+}
+
+type QuestionBankConfig struct {
+	// Mode is "offline" to ask and grade the prepared questions without a model,
+	// "llm" to let the model ask them and grade free text against the reference,
+	// or empty to ignore the question banks.
+	Mode string `json:"mode"`
+	// Questions is the number of prepared questions per topic, 5 if not set.
+	Questions int `json:"questions"`
+}
+
+type PanelConfig struct {
+	// Models grade the transcript, the interview model is used if empty.
+	Models []string `json:"models"`
+	// Samples is the number of gradings per model, 1 if not set.
+	Samples int `json:"samples"`
+	// Consensus is either "median" or "trimmed_mean".
+	Consensus string `json:"consensus"`
+	// DisagreementThreshold is the spread between the highest and lowest rating
+	// from which on the panel disagrees. 0 uses a quarter of the rubric scale.
+	DisagreementThreshold int `json:"disagreement_threshold"`
+}
+
+// Enabled reports whether transcripts are graded by a panel at all.
+func (pc PanelConfig) Enabled() bool {
+	return len(pc.Models) > 0 || pc.Samples > 1
+}
+
+// Members returns one model name per grading, models with several samples are repeated.
+func (pc PanelConfig) Members() []string {
+	models := pc.Models
+	if len(models) == 0 {
+		models = []string{appConfig.Model}
+	}
+	samples := max(pc.Samples, 1)
+	members := make([]string, 0, len(models)*samples)
+	for _, model := range models {
+		for range samples {
+			members = append(members, model)
+		}
+	}
+	return members
+}
+
+// Threshold returns the disagreement threshold on the scale of the rubric.
+func (pc PanelConfig) Threshold(r Rubric) int {
+	if pc.DisagreementThreshold > 0 {
+		return pc.DisagreementThreshold
+	}
+	return max((r.Max-r.Min)/4, 1)
+}
+
+var appConfig = Config{
+	Model:  defaultModelName,
+	Prompt: defaultPromptName,
+	Rubric: rubricPresets["default"],
+	Interview: InterviewConfig{
+		WarmupQuestions:       1,
+		MaxTechnicalQuestions: 3,
+		Adaptive:              true,
+	},
+	SelfAssessment: true,
+	Theme:          defaultThemeName,
+	Panel: PanelConfig{
+		Consensus: consensusMedian,
+	},
+}
+
+func getConfigLocation() string {
+	return filepath.Join(getDataDir(), "config.json")
+}
+
+func LoadConfig() {
+	name := getConfigLocation()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "error reading config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := json.Unmarshal(data, &appConfig); err != nil {
+		fmt.Fprintf(os.Stderr, "error unmarshalling config %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	if appConfig.Interview.WarmupQuestions < 0 || appConfig.Interview.MaxTechnicalQuestions < 1 || appConfig.Interview.MaxTurns < 0 {
+		fmt.Fprintf(os.Stderr, "error in config %s: interview needs at least one technical question and no negative limits\n", name)
+		os.Exit(1)
+	}
+	for _, c := range appConfig.Challenges {
+		if c.Topic == "" || c.Title == "" || c.File == "" || len(c.Command) == 0 {
+			fmt.Fprintf(os.Stderr, "error in config %s: challenge %q needs a topic, title, file and command\n", name, c.Title)
+			os.Exit(1)
+		}
+	}
+	if appConfig.Panel.Consensus != consensusMedian && appConfig.Panel.Consensus != consensusTrimmedMean {
+		fmt.Fprintf(os.Stderr, "error in config %s: panel consensus must be %q or %q\n", name, consensusMedian, consensusTrimmedMean)
+		os.Exit(1)
+	}
+	if keys, err = newKeyMap(appConfig.Keys); err != nil {
+		fmt.Fprintf(os.Stderr, "error in config %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	if mode := appConfig.QuestionBank.Mode; mode != "" && mode != questionBankOffline && mode != questionBankLLM {
+		fmt.Fprintf(os.Stderr, "error in config %s: question bank mode must be %q or %q\n", name, questionBankOffline, questionBankLLM)
+		os.Exit(1)
+	}
+}
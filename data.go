@@ -6,6 +6,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
 type (
@@ -17,8 +18,28 @@ type (
 	SubCategory struct {
 		Name        string `json:"name"`
 		Description string `json:"-"`
-		Score       int    `json:"score"` // 1 to 100
+		Score       int    `json:"score"` // on the scale of the rubric, 0 if not assessed
 		Comment     string `json:"comment"`
+		// SelfScore is the rating the user gave themself on the scale of the rubric, 0 if not given.
+		SelfScore int `json:"self_score,omitempty"`
+		// Confidence is 0 to 1, Uncertainty the ± range of the score on the scale of the rubric.
+		Confidence  float64 `json:"confidence,omitempty"`
+		Uncertainty int     `json:"uncertainty,omitempty"`
+		// Panel holds the independent ratings the score is the consensus of.
+		Panel        []PanelRating `json:"panel,omitempty"`
+		Disagreement bool          `json:"disagreement,omitempty"`
+
+		// Prompt is the name@version of the library prompt the score was produced with.
+		Prompt     string `json:"prompt,omitempty"`
+		PromptHash string `json:"prompt_hash,omitempty"`
+		Model      string `json:"model,omitempty"`
+		Rubric     string `json:"rubric,omitempty"`
+		// OriginalScore is the rating before the user appealed it, Appeal their argument.
+		OriginalScore int    `json:"original_score,omitempty"`
+		Appeal        string `json:"appeal,omitempty"`
+
+		// Transcript is the ID of the transcript of the interview that produced the score.
+		Transcript string `json:"transcript,omitempty"`
 	}
 )
 
@@ -31,6 +52,15 @@ func (mc MainCategory) Score() int {
 	return int(math.Round(float64(score) / float64(len(mc.SubCategories))))
 }
 
+// Uncertainty returns the ± range of the main category score, combined from the uncertainties of its sub categories.
+func (mc MainCategory) Uncertainty() int {
+	var variance float64
+	for _, sc := range mc.SubCategories {
+		variance += float64(sc.Uncertainty * sc.Uncertainty)
+	}
+	return int(math.Round(math.Sqrt(variance) / float64(len(mc.SubCategories))))
+}
+
 var (
 	mainCategoryIndex int
 	subCategoryIndex  int
@@ -54,6 +84,23 @@ func GetCurrentCategory() string {
 	)
 }
 
+// GetCurrentPosition returns the 1-based position of the current sub category among all sub categories,
+// their total and how many of them are assessed.
+func GetCurrentPosition() (position, total, assessed int) {
+	for i, mc := range Categories {
+		for j, sc := range mc.SubCategories {
+			total++
+			if i < mainCategoryIndex || (i == mainCategoryIndex && j <= subCategoryIndex) {
+				position++
+			}
+			if sc.Score > 0 {
+				assessed++
+			}
+		}
+	}
+	return position, total, assessed
+}
+
 func GetCurrentCategoryScore() int {
 	return Categories[mainCategoryIndex].SubCategories[subCategoryIndex].Score
 }
@@ -62,10 +109,68 @@ func ApplyComment(comment string) {
 	Categories[mainCategoryIndex].SubCategories[subCategoryIndex].Comment = comment
 }
 
+func ApplySelfScore(score int) {
+	Categories[mainCategoryIndex].SubCategories[subCategoryIndex].setSelfScore(score)
+}
+
+// setSelfScore stores a self rating on the scale of the configured rubric.
+func (sc *SubCategory) setSelfScore(score int) {
+	sc.SelfScore = appConfig.Rubric.Clamp(score)
+	// the self score may be stored before there is a rating that stamps the rubric
+	sc.Rubric = appConfig.Rubric.Name
+}
+
+// ApplyConfidence records how reliable the rating of the current sub category is.
+func ApplyConfidence(confidence float64, uncertainty int) {
+	sc := &Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	sc.Confidence = confidence
+	sc.Uncertainty = uncertainty
+}
+
+// ApplyAppeal records the rating the user appealed and their argument, 0 and "" for a new rating.
+func ApplyAppeal(original int, argument string) {
+	sc := &Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	sc.OriginalScore = original
+	sc.Appeal = argument
+}
+
+// ApplyPanel records the ratings of the grading panel for the current sub category.
+func ApplyPanel(ratings []PanelRating, disagreement bool) {
+	sc := &Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	sc.Panel = ratings
+	sc.Disagreement = disagreement
+}
+
+// ApplyStamp records which prompt, model and rubric produced the rating of the current sub category.
+func ApplyStamp(prompt, promptHash, model, rubric string) {
+	sc := &Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	sc.Prompt = prompt
+	sc.PromptHash = promptHash
+	sc.Model = model
+	sc.Rubric = rubric
+}
+
+// ApplyRating stores the rating of the current sub category and shows it to the user,
+// who decides whether to move on.
 func ApplyRating(score int) {
-	score = clamp(0, score, 100)
+	score = appConfig.Rubric.Clamp(score)
 	Categories[mainCategoryIndex].SubCategories[subCategoryIndex].Score = score
+	program.Send(ScoresMessage{Categories: snapshotCategories()})
 	go SaveScores()
+	sendResult()
+}
+
+// snapshotCategories returns a copy of the categories the UI can read while the interview goes on.
+func snapshotCategories() []MainCategory {
+	snapshot := make([]MainCategory, len(Categories))
+	for i, mc := range Categories {
+		snapshot[i] = MainCategory{Name: mc.Name, SubCategories: slices.Clone(mc.SubCategories)}
+	}
+	return snapshot
+}
+
+// Advance moves on to the next sub category that needs an interview and begins it.
+func Advance() {
 	for {
 		subCategoryIndex++
 		if subCategoryIndex >= len(Categories[mainCategoryIndex].SubCategories) {
@@ -76,7 +181,7 @@ func ApplyRating(score int) {
 			mainCategoryIndex = 0
 			subCategoryIndex = 0
 			// no more categories, end the program
-			teaProgram.Quit()
+			program.Quit()
 			return
 		}
 		if RedoTakenTests || GetCurrentCategoryScore() <= 0 {
@@ -391,20 +496,25 @@ func LoadSaveScores() {
 
 		// apply saved scores without overwriting the sub categories
 		for _, storedCat := range storedCats {
-			if storedCat.Score() == 0 {
-				continue
-			}
 			// find the same category in the current list
 			for i, cat := range Categories {
 				if cat.Name == storedCat.Name {
 					for _, storedSubCat := range storedCat.SubCategories {
-						if storedSubCat.Score == 0 {
+						if storedSubCat.Score == 0 && storedSubCat.SelfScore == 0 {
 							continue
 						}
+						if !storedSubCat.rescale(appConfig.Rubric) {
+							// the scale is unknown, stop before SaveScores overwrites the rating
+							fmt.Fprintf(os.Stderr, "error reading %s: %s (%s) was rated on the rubric %q which is no longer configured, configure it as rubric again\n",
+								name, storedSubCat.Name, storedCat.Name, storedSubCat.Rubric)
+							os.Exit(1)
+						}
 						// find the same sub category in the current list
 						for j, subCat := range cat.SubCategories {
 							if subCat.Name == storedSubCat.Name {
-								Categories[i].SubCategories[j].Score = storedSubCat.Score
+								// the description is not stored, everything else is the saved assessment
+								storedSubCat.Description = subCat.Description
+								Categories[i].SubCategories[j] = storedSubCat
 								break
 							}
 						}
@@ -416,6 +526,37 @@ func LoadSaveScores() {
 	}
 }
 
+// rescale moves the stored scores of a sub category to the given rubric if they were rated on another one.
+// It returns false if the scale they were rated on is unknown.
+func (sc *SubCategory) rescale(to Rubric) bool {
+	name := sc.Rubric
+	if name == "" {
+		// stored before the rubric was recorded, when there was only the default scale
+		name = "default"
+	}
+	if name == to.Name {
+		return true
+	}
+	from, ok := rubricPresets[name]
+	if !ok {
+		return false
+	}
+	sc.Score = to.Rescale(sc.Score, from)
+	sc.SelfScore = to.Rescale(sc.SelfScore, from)
+	sc.OriginalScore = to.Rescale(sc.OriginalScore, from)
+	sc.Uncertainty = to.RescaleRange(sc.Uncertainty, from)
+	for i := range sc.Panel {
+		sc.Panel[i].Rating = to.Rescale(sc.Panel[i].Rating, from)
+	}
+	sc.Rubric = to.Name
+	return true
+}
+
+// getDataDir returns the directory that holds the data file and everything stored next to it.
+func getDataDir() string {
+	return filepath.Dir(getDataStoreLocation())
+}
+
 func SaveScores() {
 	name := getDataStoreLocation()
 	_ = os.MkdirAll(filepath.Dir(name), 0755)
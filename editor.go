@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorFinishedMessage carries the text of a file after the editor was closed.
+type EditorFinishedMessage struct {
+	Purpose string // what the text is for, "answer" or "challenge"
+	Content string
+	Err     error
+}
+
+// editorCommand returns the editor of the user from $VISUAL or $EDITOR, "" if none is set.
+func editorCommand() string {
+	if visual := os.Getenv("VISUAL"); visual != "" {
+		return visual
+	}
+	return os.Getenv("EDITOR")
+}
+
+// openEditor opens content in the editor of the user and sends an EditorFinishedMessage when it is closed.
+// pattern is used for the temporary file name, its extension lets the editor pick a syntax.
+func openEditor(purpose, content, pattern string) tea.Cmd {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return func() tea.Msg { return EditorFinishedMessage{Purpose: purpose, Err: err} }
+	}
+	name := f.Name()
+	_, err = f.WriteString(content)
+	f.Close()
+	if err != nil {
+		os.Remove(name)
+		return func() tea.Msg { return EditorFinishedMessage{Purpose: purpose, Err: err} }
+	}
+
+	// the editor may come with arguments, e.g. "code --wait"
+	args := strings.Fields(editorCommand())
+	if len(args) == 0 {
+		os.Remove(name)
+		return func() tea.Msg {
+			return EditorFinishedMessage{Purpose: purpose, Err: errors.New("neither $VISUAL nor $EDITOR is set")}
+		}
+	}
+	cmd := exec.Command(args[0], append(args[1:], name)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(name)
+		if err != nil {
+			return EditorFinishedMessage{Purpose: purpose, Err: err}
+		}
+		data, err := os.ReadFile(name)
+		return EditorFinishedMessage{Purpose: purpose, Content: string(data), Err: err}
+	})
+}
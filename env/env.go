@@ -14,13 +14,7 @@ var (
 func init() {
 	loadEnv()
 
-	if googleApiKey, ok := os.LookupEnv("GOOGLE_API_KEY"); ok {
-		GOOGLE_API_KEY = googleApiKey
-	} else {
-		fmt.Fprintln(os.Stderr, "GOOGLE_API_KEY is not set")
-		os.Exit(1)
-		return
-	}
+	GOOGLE_API_KEY = os.Getenv("GOOGLE_API_KEY")
 }
 
 func loadEnv() {
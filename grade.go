@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/genai"
+)
+
+const (
+	// scale of the correctness and depth of a graded answer
+	answerGradeMin = 1
+	answerGradeMax = 5
+)
+
+const gradingNote = `
+
+GRADING
+   • With every response grade the candidate's latest answer in "grade",
+     leave it out only if there is no answer yet:
+     – correctness: %[1]d = wrong or no knowledge, 3 = partially correct, %[2]d = fully correct
+     – depth: %[1]d = superficial, 3 = solid, %[2]d = expert-level detail and trade-offs
+     – confidence: 0.0–1.0, how sure you are about this grade
+     – misconceptions: short list of wrong beliefs the answer revealed, empty if none`
+
+// AnswerGrade is the judgement of the model about a single answer of the candidate.
+type AnswerGrade struct {
+	Correctness    int      `json:"correctness"`
+	Depth          int      `json:"depth"`
+	Confidence     float64  `json:"confidence"`
+	Misconceptions []string `json:"misconceptions,omitempty"`
+}
+
+// String returns the grade for people, e.g. "correctness 4/5 · depth 3/5".
+func (g AnswerGrade) String() string {
+	return fmt.Sprintf("correctness %d/%d · depth %d/%d", g.Correctness, answerGradeMax, g.Depth, answerGradeMax)
+}
+
+func gradeSchema() *genai.Schema {
+	return &genai.Schema{
+		Type:     genai.TypeObject,
+		Nullable: genai.Ptr(true),
+		Properties: map[string]*genai.Schema{
+			"correctness": {
+				Type:    genai.TypeInteger,
+				Minimum: genai.Ptr(float64(answerGradeMin)),
+				Maximum: genai.Ptr(float64(answerGradeMax)),
+			},
+			"depth": {
+				Type:    genai.TypeInteger,
+				Minimum: genai.Ptr(float64(answerGradeMin)),
+				Maximum: genai.Ptr(float64(answerGradeMax)),
+			},
+			"confidence": {
+				Type:    genai.TypeNumber,
+				Minimum: genai.Ptr(0.0),
+				Maximum: genai.Ptr(1.0),
+			},
+			"misconceptions": {
+				Type:  genai.TypeArray,
+				Items: &genai.Schema{Type: genai.TypeString},
+			},
+		},
+		Required: []string{"correctness", "depth", "confidence", "misconceptions"},
+	}
+}
+
+// normalize keeps a grade returned by the model within its scales.
+func (g *AnswerGrade) normalize() {
+	g.Correctness = clamp(answerGradeMin, g.Correctness, answerGradeMax)
+	g.Depth = clamp(answerGradeMin, g.Depth, answerGradeMax)
+	g.Confidence = min(max(g.Confidence, 0), 1)
+}
+
+// defaultConfidence is assumed when the model did not state how sure it is.
+const defaultConfidence = 0.5
+
+// gradesOf returns the grades of all answers in a conversation.
+func gradesOf(entries []AiMessageHistoryEntry) []AnswerGrade {
+	var grades []AnswerGrade
+	for _, entry := range entries {
+		if entry.Grade != nil {
+			grades = append(grades, *entry.Grade)
+		}
+	}
+	return grades
+}
+
+// ratingConfidence combines the confidence of the model in its rating
+// with its confidence in the grades of the single answers.
+func ratingConfidence(modelConfidence *float64, grades []AnswerGrade) float64 {
+	confidence := defaultConfidence
+	if modelConfidence != nil {
+		confidence = min(max(*modelConfidence, 0), 1)
+	}
+	if len(grades) == 0 {
+		return confidence
+	}
+	var answers float64
+	for _, g := range grades {
+		answers += g.Confidence
+	}
+	return (confidence + answers/float64(len(grades))) / 2
+}
+
+// ratingUncertainty returns the ± range of a rating on the rubric.
+// It starts at half the scale, shrinks with the confidence and with the square root of the number of graded answers.
+func ratingUncertainty(r Rubric, confidence float64, graded int) int {
+	halfRange := float64(r.Max-r.Min) / 2
+	return int(math.Round(halfRange * (1 - confidence) / math.Sqrt(float64(max(graded, 1)))))
+}
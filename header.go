@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// headerHeight is the number of lines of the header above the conversation.
+const headerHeight = 4
+
+// header shows the current topic and how far the session has come.
+type header struct {
+	topic    NewCategoryMessage
+	asked    int
+	progress progress.Model
+}
+
+func newHeader() header {
+	return header{
+		progress: progress.New(progress.WithSolidFill(string(theme.Accent)), progress.WithoutPercentage()),
+	}
+}
+
+func (h header) view(width int) string {
+	if h.topic.Total == 0 {
+		return lipgloss.NewStyle().Height(headerHeight).Render("")
+	}
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	position := fmt.Sprintf("%d/%d", h.topic.Position, h.topic.Total)
+	title := lipgloss.NewStyle().MaxWidth(max(width-len(position)-1, 0)).Render(
+		titleStyle.Render(h.topic.SubCategory) + dimStyle.Render(" · "+h.topic.MainCategory),
+	)
+	gap := max(width-lipgloss.Width(title)-len(position), 1)
+	titleLine := title + fmt.Sprintf("%*s", gap, "") + position
+
+	// one line only, the layout reserves exactly headerHeight lines
+	description := dimStyle.MaxWidth(width).MaxHeight(1).Render(strings.Join(strings.Fields(h.topic.Description), " "))
+
+	questions := fmt.Sprintf("question %d of ~%d", h.asked, h.topic.Questions)
+	if h.asked == 0 {
+		questions = fmt.Sprintf("~%d questions", h.topic.Questions)
+	}
+	assessed := fmt.Sprintf("%d assessed", h.topic.Assessed)
+	status := dimStyle.Render(questions + " • " + assessed)
+	h.progress.Width = max(width-lipgloss.Width(status)-2, 10)
+	bar := h.progress.ViewAs(float64(h.topic.Position-1) / float64(h.topic.Total))
+	progressLine := lipgloss.NewStyle().MaxWidth(width).Render(bar + "  " + status)
+
+	return lipgloss.JoinVertical(lipgloss.Left, titleLine, description, progressLine, "")
+}
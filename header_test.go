@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHeaderHeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic NewCategoryMessage
+		width int
+	}{
+		{"before the first topic", NewCategoryMessage{}, 80},
+		{"one line description", NewCategoryMessage{SubCategory: "Sub", MainCategory: "Main", Description: "Short.", Position: 1, Total: 3, Questions: 5}, 80},
+		{"description with line breaks", NewCategoryMessage{SubCategory: "Sub", MainCategory: "Main", Description: "First line\nsecond line\n\nthird line", Position: 2, Total: 3, Questions: 5}, 80},
+		{"narrow terminal", NewCategoryMessage{SubCategory: "A rather long sub category", MainCategory: "Main", Description: "A description that is much longer than the terminal is wide.", Position: 3, Total: 3, Questions: 5}, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHeader()
+			h.topic = tt.topic
+			if got := lipgloss.Height(h.view(tt.width)); got != headerHeight {
+				t.Errorf("header is %d lines high, want %d", got, headerHeight)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyMap holds the key bindings of the interview TUI. Every binding can be changed
+// in the "keys" section of the config by its action name, see keyActions.
+type keyMap struct {
+	Send    key.Binding
+	NewLine key.Binding
+	Editor  key.Binding
+	Undo    key.Binding
+	Redo    key.Binding
+	Quit    key.Binding
+	Topics  key.Binding
+	// Transcripts opens the transcript browser, Search its search field.
+	Transcripts key.Binding
+	Search      key.Binding
+	// SelectMessage starts the selection of a message in the conversation, Copy copies it.
+	SelectMessage key.Binding
+	Copy          key.Binding
+	Export        key.Binding
+
+	// lists of choice questions and the sidebar
+	Up         key.Binding
+	Down       key.Binding
+	MoveUp     key.Binding
+	MoveDown   key.Binding
+	Toggle     key.Binding
+	TextAnswer key.Binding
+	Select     key.Binding
+	Close      key.Binding
+
+	// result of a topic
+	Accept  key.Binding
+	Appeal  key.Binding
+	Dispute key.Binding
+	Retake  key.Binding
+
+	// Scroll only describes the mouse wheel in the help, it is not configurable.
+	Scroll key.Binding
+}
+
+// keyAction is a configurable action with its default keys.
+type keyAction struct {
+	name        string
+	description string
+	keys        []string
+	binding     func(*keyMap) *key.Binding
+}
+
+var keyActions = []keyAction{
+	{"send", "send", []string{"ctrl+y"}, func(k *keyMap) *key.Binding { return &k.Send }},
+	{"new_line", "new line", []string{"enter"}, func(k *keyMap) *key.Binding { return &k.NewLine }},
+	{"editor", "editor", []string{"ctrl+e"}, func(k *keyMap) *key.Binding { return &k.Editor }},
+	{"undo", "undo", []string{"ctrl+z"}, func(k *keyMap) *key.Binding { return &k.Undo }},
+	{"redo", "redo", []string{"ctrl+r"}, func(k *keyMap) *key.Binding { return &k.Redo }},
+	{"quit", "quit", []string{"ctrl+c"}, func(k *keyMap) *key.Binding { return &k.Quit }},
+	{"topics", "topics", []string{"ctrl+t"}, func(k *keyMap) *key.Binding { return &k.Topics }},
+	{"transcripts", "transcripts", []string{"ctrl+o"}, func(k *keyMap) *key.Binding { return &k.Transcripts }},
+	{"search", "search", []string{"/"}, func(k *keyMap) *key.Binding { return &k.Search }},
+	{"select_message", "select message", []string{"ctrl+l"}, func(k *keyMap) *key.Binding { return &k.SelectMessage }},
+	{"copy", "copy", []string{"y", "enter"}, func(k *keyMap) *key.Binding { return &k.Copy }},
+	{"export", "export", []string{"ctrl+x"}, func(k *keyMap) *key.Binding { return &k.Export }},
+	{"up", "up", []string{"up", "k"}, func(k *keyMap) *key.Binding { return &k.Up }},
+	{"down", "down", []string{"down", "j"}, func(k *keyMap) *key.Binding { return &k.Down }},
+	{"move_up", "move item up", []string{"shift+up", "K"}, func(k *keyMap) *key.Binding { return &k.MoveUp }},
+	{"move_down", "move item down", []string{"shift+down", "J"}, func(k *keyMap) *key.Binding { return &k.MoveDown }},
+	{"toggle", "select", []string{" ", "x"}, func(k *keyMap) *key.Binding { return &k.Toggle }},
+	{"text_answer", "answer as text", []string{"tab"}, func(k *keyMap) *key.Binding { return &k.TextAnswer }},
+	{"select", "interview this topic", []string{"enter"}, func(k *keyMap) *key.Binding { return &k.Select }},
+	{"close", "close", []string{"esc"}, func(k *keyMap) *key.Binding { return &k.Close }},
+	{"accept", "accept and continue", []string{"enter"}, func(k *keyMap) *key.Binding { return &k.Accept }},
+	{"appeal", "appeal", []string{"a"}, func(k *keyMap) *key.Binding { return &k.Appeal }},
+	{"dispute", "dispute", []string{"d"}, func(k *keyMap) *key.Binding { return &k.Dispute }},
+	{"retake", "retake", []string{"r"}, func(k *keyMap) *key.Binding { return &k.Retake }},
+}
+
+// keys is the key map of the interview TUI, replaced by LoadConfig if the config changes bindings.
+var keys = mustKeyMap(nil)
+
+// newKeyMap returns the default key map with the keys of the given actions replaced.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	k := keyMap{}
+	for name := range overrides {
+		if !slices.ContainsFunc(keyActions, func(a keyAction) bool { return a.name == name }) {
+			return k, fmt.Errorf("unknown key action %q", name)
+		}
+	}
+	for _, action := range keyActions {
+		bound := action.keys
+		if override, ok := overrides[action.name]; ok {
+			if len(override) == 0 {
+				return k, fmt.Errorf("key action %q needs at least one key", action.name)
+			}
+			bound = override
+		}
+		*action.binding(&k) = key.NewBinding(
+			key.WithKeys(bound...),
+			key.WithHelp(keyNames(bound), action.description),
+		)
+	}
+	k.Scroll = helpOnly("mouse wheel", "scroll")
+	if len(overlap(k.Send, k.NewLine)) > 0 {
+		return k, fmt.Errorf("send and new_line share the key %q", overlap(k.Send, k.NewLine)[0])
+	}
+	return k, nil
+}
+
+func mustKeyMap(overrides map[string][]string) keyMap {
+	k, err := newKeyMap(overrides)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// ShortHelp returns the bindings of the interview screen, keyMap implements help.KeyMap.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.NewLine, k.Send, k.Editor, k.Topics, k.Transcripts, k.SelectMessage, k.Export, k.Quit, k.Scroll}
+}
+
+// FullHelp returns all bindings grouped by the screen they belong to.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		k.ShortHelp(),
+		{k.Undo, k.Redo, k.Search, k.Copy},
+		{k.Up, k.Down, k.MoveUp, k.MoveDown, k.Toggle, k.TextAnswer, k.Select, k.Close},
+		{k.Accept, k.Appeal, k.Dispute, k.Retake},
+	}
+}
+
+// helpOnly returns a binding that describes an input in the help but never matches a key, e.g. the mouse wheel.
+func helpOnly(input, description string) key.Binding {
+	return key.NewBinding(key.WithKeys(input), key.WithHelp(input, description))
+}
+
+// either returns a binding that matches the keys of both, with the help description of the first.
+func either(a, b key.Binding) key.Binding {
+	bound := append(slices.Clone(a.Keys()), b.Keys()...)
+	return key.NewBinding(key.WithKeys(bound...), key.WithHelp(keyNames(bound), a.Help().Desc))
+}
+
+// overlap returns the keys two bindings have in common.
+func overlap(a, b key.Binding) []string {
+	var common []string
+	for _, k := range a.Keys() {
+		if slices.Contains(b.Keys(), k) {
+			common = append(common, k)
+		}
+	}
+	return common
+}
+
+// keyNames returns the keys for the help, e.g. "Ctrl+Y" or "↑/k".
+func keyNames(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, "/")
+}
+
+func keyName(k string) string {
+	switch k {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	parts := strings.Split(k, "+")
+	if len(parts) == 1 && len([]rune(k)) == 1 {
+		return k // plain characters as typed
+	}
+	for i, part := range parts {
+		switch part {
+		case "up", "down", "left", "right":
+			parts[i] = keyName(part)
+		default:
+			runes := []rune(part)
+			runes[0] = unicode.ToUpper(runes[0])
+			parts[i] = string(runes)
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// newHelp returns a help bubble in the colours of the theme.
+func newHelp() help.Model {
+	h := help.New()
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Dim).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	h.Styles = help.Styles{
+		Ellipsis:       dimStyle,
+		ShortKey:       keyStyle,
+		ShortDesc:      dimStyle,
+		ShortSeparator: dimStyle,
+		FullKey:        keyStyle,
+		FullDesc:       dimStyle,
+		FullSeparator:  dimStyle,
+	}
+	return h
+}
+
+// helpView returns the help line for the enabled bindings, e.g. "Ctrl+Y send • Ctrl+C quit".
+func helpView(bindings ...key.Binding) string {
+	return newHelp().ShortHelpView(bindings)
+}
@@ -5,26 +5,91 @@ import (
 	"os"
 	"time"
 
+	"profiler/env"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 )
 
-var teaProgram *tea.Program
+// frontend is the user interface the interview engine sends its messages to.
+type frontend interface {
+	Send(msg tea.Msg)
+	Quit()
+}
+
+var program frontend
 var RedoTakenTests = false
 
 func main() {
+	LoadConfig()
 	LoadSaveScores()
-	teaProgram = tea.NewProgram(
-		initialModel(),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
-	go func() {
-		time.Sleep(time.Millisecond * 50)
-		Begin()
-	}()
-	if _, err := teaProgram.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	LoadPrompt()
+
+	plain := len(os.Args) > 1 && os.Args[1] == "--plain"
+	if len(os.Args) > 1 && !plain {
+		switch os.Args[1] {
+		case "plan":
+			InitLLM()
+			if err := Plan(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "regrade":
+			InitLLM()
+			if err := Regrade(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "survey":
+			LoadTheme()
+			if err := Survey(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "transcripts":
+			LoadTheme()
+			if err := Transcripts(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "prompts":
+			if err := ListPrompts(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+			os.Exit(1)
+		}
+		return
+	}
+
+	// offline question banks work without a model, topics without one are skipped
+	if appConfig.QuestionBank.Mode != questionBankOffline || env.GOOGLE_API_KEY != "" {
+		InitLLM()
+	}
+	// without a terminal the interview falls back to plain lines
+	if plain || !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		p := newPlainFrontend(os.Stdout)
+		program = p
+		go Begin()
+		p.Run(os.Stdin)
+	} else {
+		LoadTheme()
+		teaProgram := tea.NewProgram(
+			initialModel(),
+			tea.WithAltScreen(),
+			tea.WithMouseCellMotion(),
+		)
+		program = teaProgram
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			Begin()
+		}()
+		if _, err := teaProgram.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	if quitErr != nil {
@@ -32,10 +97,5 @@ func main() {
 		os.Exit(1)
 	}
 
-	for _, category := range Categories {
-		fmt.Printf("%s: %d\n", category.Name, category.Score())
-		for _, subCategory := range category.SubCategories {
-			fmt.Printf("  %s: %d\n", subCategory.Name, subCategory.Score)
-		}
-	}
+	PrintReport()
 }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"google.golang.org/genai"
+)
+
+const (
+	consensusMedian      = "median"
+	consensusTrimmedMean = "trimmed_mean"
+)
+
+// PanelRating is the rating of one member of the grading panel.
+type PanelRating struct {
+	Model       string  `json:"model"`
+	Rating      int     `json:"rating"`
+	Comment     string  `json:"comment"`
+	Confidence  float64 `json:"confidence"`
+	Interviewer bool    `json:"interviewer,omitempty"` // the rating given during the interview
+}
+
+var gradingPrompt = template.Must(
+	template.New("grading").
+		Parse(`You are grading a finished technical interview on {{.Topic}}.
+You did not take part in the interview. Judge only what the candidate said.
+
+Rate the candidate's expertise with an integer from {{.Rubric.Min}}–{{.Rubric.Max}}:
+{{- range .Rubric.Levels}}
+   – {{.Value}}: {{.Name}}{{with .Description}} – {{.}}{{end}}
+{{- end}}
+Provide 1–2 sentences of actionable feedback on how to improve,
+and how confident you are in the rating from 0.0 to 1.0.
+{{- with .Extra}}
+
+{{.}}
+{{- end}}
+
+TRANSCRIPT
+
+{{.Conversation}}`))
+
+// gradeTranscript asks a model to rate a transcript without taking part in the conversation.
+// extra is added to the instructions, e.g. for appeals.
+func gradeTranscript(ctx context.Context, model string, t Transcript, extra string) (PanelRating, error) {
+	sb := strings.Builder{}
+	err := gradingPrompt.Execute(&sb, map[string]any{
+		"Topic":        t.Topic(),
+		"Rubric":       t.Rubric,
+		"Conversation": t.Conversation(),
+		"Extra":        extra,
+	})
+	if err != nil {
+		return PanelRating{}, errors.Join(errors.New("failed to execute grading prompt template"), err)
+	}
+
+	resp, err := llmClient.Models.GenerateContent(
+		ctx,
+		model,
+		genai.Text(sb.String()),
+		&genai.GenerateContentConfig{
+			ResponseMIMEType: "application/json",
+			ResponseSchema:   ratingSchema(),
+		},
+	)
+	if err != nil {
+		return PanelRating{}, errors.Join(errors.New("failed to generate content"), err)
+	}
+
+	aiResp := AiResponse{}
+	if err := json.Unmarshal([]byte(resp.Text()), &aiResp); err != nil {
+		return PanelRating{}, errors.Join(errors.New("failed to unmarshal response"), err)
+	}
+	if aiResp.Rating == nil {
+		return PanelRating{}, fmt.Errorf("%s did not return a rating", model)
+	}
+	pr := PanelRating{
+		Model:      model,
+		Rating:     t.Rubric.Clamp(*aiResp.Rating),
+		Confidence: ratingConfidence(aiResp.Confidence, nil),
+	}
+	if aiResp.Comment != nil {
+		pr.Comment = *aiResp.Comment
+	}
+	return pr, nil
+}
+
+// RunPanel lets every configured panel member grade the transcript independently,
+// extra is added to the grading prompt of each of them.
+func RunPanel(ctx context.Context, t Transcript, extra string) ([]PanelRating, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		ratings []PanelRating
+		errs    []error
+	)
+	for _, model := range appConfig.Panel.Members() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pr, err := gradeTranscript(ctx, model, t, extra)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			ratings = append(ratings, pr)
+		}()
+	}
+	wg.Wait()
+	if len(ratings) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return ratings, nil
+}
+
+// panelVerdict returns the consensus rating with the comment of the panel member closest to it
+// and the mean confidence of the panel.
+func panelVerdict(rating int, ratings []PanelRating) PanelRating {
+	verdict := PanelRating{Rating: rating}
+	distance := -1
+	for _, pr := range ratings {
+		if d := max(pr.Rating-rating, rating-pr.Rating); distance < 0 || d < distance {
+			distance = d
+			verdict.Model, verdict.Comment = pr.Model, pr.Comment
+		}
+		verdict.Confidence += pr.Confidence / float64(len(ratings))
+	}
+	return verdict
+}
+
+// Consensus returns the combined rating of the panel and whether the panel disagrees.
+func Consensus(r Rubric, ratings []PanelRating) (int, bool) {
+	values := make([]int, 0, len(ratings))
+	for _, pr := range ratings {
+		values = append(values, pr.Rating)
+	}
+	slices.Sort(values)
+
+	var rating int
+	switch appConfig.Panel.Consensus {
+	case consensusTrimmedMean:
+		// drop the highest and lowest rating if there are enough to spare
+		trimmed := values
+		if len(trimmed) >= 3 {
+			trimmed = trimmed[1 : len(trimmed)-1]
+		}
+		var sum int
+		for _, v := range trimmed {
+			sum += v
+		}
+		rating = int(math.Round(float64(sum) / float64(len(trimmed))))
+	default:
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			rating = int(math.Round(float64(values[mid-1]+values[mid]) / 2))
+		} else {
+			rating = values[mid]
+		}
+	}
+
+	disagreement := values[len(values)-1]-values[0] > appConfig.Panel.Threshold(r)
+	return r.Clamp(rating), disagreement
+}
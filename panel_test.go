@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConsensus(t *testing.T) {
+	defer func(panel PanelConfig) { appConfig.Panel = panel }(appConfig.Panel)
+
+	dreyfus := rubricPresets["dreyfus"]
+	percent := rubricPresets["default"]
+	ratings := func(values ...int) []PanelRating {
+		prs := make([]PanelRating, len(values))
+		for i, v := range values {
+			prs[i] = PanelRating{Rating: v}
+		}
+		return prs
+	}
+	tests := []struct {
+		name             string
+		consensus        string
+		threshold        int
+		rubric           Rubric
+		ratings          []PanelRating
+		want             int
+		wantDisagreement bool
+	}{
+		{"median of one", consensusMedian, 0, percent, ratings(40), 40, false},
+		{"median odd", consensusMedian, 0, percent, ratings(60, 40, 50), 50, false},
+		{"median even rounds half up", consensusMedian, 0, percent, ratings(40, 51), 46, false},
+		{"median ignores outlier", consensusMedian, 0, percent, ratings(50, 55, 100), 55, true},
+		{"trimmed mean of two keeps both", consensusTrimmedMean, 0, percent, ratings(40, 60), 50, false},
+		{"trimmed mean drops extremes", consensusTrimmedMean, 0, percent, ratings(1, 50, 60, 100), 55, true},
+		{"trimmed mean of three is the middle", consensusTrimmedMean, 0, dreyfus, ratings(1, 3, 5), 3, true},
+		{"ties", consensusTrimmedMean, 0, dreyfus, ratings(4, 4, 4), 4, false},
+		{"spread at the default threshold agrees", consensusMedian, 0, dreyfus, ratings(3, 4), 4, false},
+		{"spread above the default threshold disagrees", consensusMedian, 0, dreyfus, ratings(2, 4), 3, true},
+		{"configured threshold", consensusMedian, 30, percent, ratings(40, 70), 55, false},
+		{"clamped to the rubric", consensusMedian, 0, dreyfus, ratings(7, 8), 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appConfig.Panel = PanelConfig{Consensus: tt.consensus, DisagreementThreshold: tt.threshold}
+			got, disagreement := Consensus(tt.rubric, tt.ratings)
+			if got != tt.want || disagreement != tt.wantDisagreement {
+				t.Errorf("Consensus(%v) = %d, %v, want %d, %v", tt.ratings, got, disagreement, tt.want, tt.wantDisagreement)
+			}
+		})
+	}
+}
+
+func TestPanelVerdict(t *testing.T) {
+	ratings := []PanelRating{
+		{Model: "a", Rating: 20, Comment: "weak", Confidence: 0.5},
+		{Model: "b", Rating: 60, Comment: "solid", Confidence: 0.7},
+		{Model: "c", Rating: 90, Comment: "strong", Confidence: 0.9},
+	}
+	got := panelVerdict(60, ratings)
+	want := PanelRating{Model: "b", Rating: 60, Comment: "solid", Confidence: 0.7}
+	if got.Model != want.Model || got.Rating != want.Rating || got.Comment != want.Comment || math.Abs(got.Confidence-want.Confidence) > 1e-9 {
+		t.Errorf("panelVerdict() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// plainMode is what the plain frontend expects the next input to be.
+type plainMode int
+
+const (
+	plainIdle plainMode = iota // the engine is busy, input waits
+	plainSelfAssessment
+	plainAnswer
+	plainChoice
+	plainChallenge
+	plainResult
+	plainDispute
+	plainAppeal
+)
+
+// plainFrontend runs the interview over plain lines on stdin and stdout without any escape codes,
+// for screen readers, logs and other programs driving the interview.
+//
+// Answers end with an empty line, solutions of exercises with a line that holds only a dot.
+type plainFrontend struct {
+	out io.Writer
+
+	mu             sync.Mutex
+	mode           plainMode
+	choice         *choice
+	selfAssessment SelfAssessmentMessage
+	result         ResultMessage
+
+	ready    chan struct{} // signals that the mode changed
+	done     chan struct{}
+	quitOnce sync.Once
+}
+
+func newPlainFrontend(out io.Writer) *plainFrontend {
+	return &plainFrontend{
+		out:   out,
+		ready: make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+// setMode changes what the next input is for and wakes up the input loop.
+func (p *plainFrontend) setMode(mode plainMode) {
+	p.mode = mode
+	select {
+	case p.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (p *plainFrontend) printf(format string, a ...any) {
+	fmt.Fprintf(p.out, format, a...)
+}
+
+// Send prints a message of the engine and prepares for the input it asks for.
+func (p *plainFrontend) Send(msg tea.Msg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch msg := msg.(type) {
+	case NewCategoryMessage:
+		p.printf("\n=== %s (%s), topic %d of %d ===\n", msg.SubCategory, msg.MainCategory, msg.Position, msg.Total)
+		if msg.Description != "" {
+			p.printf("%s\n", msg.Description)
+		}
+		p.setMode(plainIdle)
+
+	case SelfAssessmentMessage:
+		p.selfAssessment = msg
+		p.printf("\nHow would you rate your own expertise in %s? Enter a number from %d to %d, or an empty line to skip.\n> ", msg.Topic, msg.Min, msg.Max)
+		p.setMode(plainSelfAssessment)
+
+	case AiThinkingMessage:
+		if msg.Thinking {
+			p.printf("\nThinking...\n")
+			p.setMode(plainIdle)
+		}
+
+	case AiMessage:
+		p.printf("\nInterviewer:\n%s\n", strings.TrimSpace(msg.Content))
+		if msg.Challenge {
+			// the exercise follows and asks for the solution
+			p.setMode(plainIdle)
+			return
+		}
+		if isChoiceQuestion(msg.QuestionType, msg.Options) {
+			p.choice = newChoice(msg.QuestionType, msg.Options)
+			for i, option := range msg.Options {
+				p.printf("%s) %s\n", optionLetter(i), option)
+			}
+			switch msg.QuestionType {
+			case questionOrdering:
+				p.printf("\nEnter all letters in the right order, e.g. \"C A B\", or answer in words.\n> ")
+			case questionMultipleChoice:
+				p.printf("\nEnter the letters of all correct options, e.g. \"A C\", or answer in words.\n> ")
+			default:
+				p.printf("\nEnter the letter of the correct option, or answer in words.\n> ")
+			}
+			p.setMode(plainChoice)
+			return
+		}
+		p.printf("\nYour answer, end it with an empty line:\n")
+		p.setMode(plainAnswer)
+
+	case ChallengeMessage:
+		p.printf("\nExercise: %s\n%s\n", msg.Challenge.Title, strings.TrimSpace(msg.Challenge.Task))
+		if msg.Starter != "" {
+			p.printf("\nStarter code of %s:\n%s\n", msg.Challenge.File, strings.TrimRight(msg.Starter, "\n"))
+		}
+		p.printf("\nEnter your solution to %s, end it with a line that holds only a dot:\n", msg.Challenge.File)
+		p.setMode(plainChallenge)
+
+	case ChallengeResultMessage:
+		p.printf("\n%s\n", strings.TrimSpace(msg.Content))
+
+	case ResultMessage:
+		p.result = msg
+		p.printf("\nResult: %s\n", msg.Content())
+		options := []string{"empty line: accept and continue"}
+		if msg.Appealable {
+			options = append(options, "a: appeal")
+		}
+		if msg.Disputable {
+			options = append(options, "d: dispute")
+		}
+		options = append(options, "r: retake")
+		p.printf("%s\n> ", strings.Join(options, ", "))
+		p.setMode(plainResult)
+	}
+}
+
+// Quit ends the input loop.
+func (p *plainFrontend) Quit() {
+	p.quitOnce.Do(func() { close(p.done) })
+}
+
+// Run reads the input of the user line by line until the interview ends or the input is closed.
+func (p *plainFrontend) Run(in io.Reader) {
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var block []string
+	for {
+		p.mu.Lock()
+		idle := p.mode == plainIdle
+		p.mu.Unlock()
+		input := lines
+		if idle {
+			// keep piped input until the engine asks for it
+			input = nil
+		}
+
+		select {
+		case <-p.done:
+			return
+		case <-p.ready:
+		case line, ok := <-input:
+			if !ok {
+				p.Quit()
+				return
+			}
+			p.mu.Lock()
+			block = p.handle(line, block)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// handle processes a line of input and returns the lines of the answer collected so far.
+func (p *plainFrontend) handle(line string, block []string) []string {
+	switch p.mode {
+	case plainSelfAssessment:
+		score, err := parseSelfScore(strings.TrimSpace(line), p.selfAssessment)
+		if err != nil {
+			p.printf("%s\n> ", err)
+			return nil
+		}
+		p.setMode(plainIdle)
+		go SubmitSelfAssessment(score)
+
+	case plainChoice:
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			p.printf("> ")
+			return nil
+		}
+		if choiceAnswer, ok := p.choice.parse(answer); ok {
+			answer = choiceAnswer
+		}
+		p.setMode(plainIdle)
+		go Continue(answer)
+
+	case plainAnswer, plainDispute, plainAppeal:
+		if strings.TrimSpace(line) != "" {
+			return append(block, line)
+		}
+		if len(block) == 0 {
+			return nil
+		}
+		answer := strings.TrimSpace(strings.Join(block, "\n"))
+		mode := p.mode
+		p.setMode(plainIdle)
+		switch mode {
+		case plainDispute:
+			go Dispute(answer)
+		case plainAppeal:
+			go Appeal(answer)
+		default:
+			go Continue(answer)
+		}
+
+	case plainChallenge:
+		if strings.TrimSpace(line) != "." {
+			return append(block, line)
+		}
+		p.setMode(plainIdle)
+		go SubmitChallenge(strings.Join(block, "\n") + "\n")
+
+	case plainResult:
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "":
+			p.setMode(plainIdle)
+			go Accept()
+		case "r":
+			p.setMode(plainIdle)
+			go Retake()
+		case "a":
+			if !p.result.Appealable {
+				p.printf("This rating cannot be appealed.\n> ")
+				return nil
+			}
+			p.printf("Argue why the rating should change, end it with an empty line:\n")
+			p.setMode(plainAppeal)
+		case "d":
+			if !p.result.Disputable {
+				p.printf("Offline ratings cannot be disputed, retake the topic instead.\n> ")
+				return nil
+			}
+			p.printf("Explain why you disagree with the rating, end it with an empty line:\n")
+			p.setMode(plainDispute)
+		default:
+			p.printf("Unknown choice.\n> ")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"google.golang.org/genai"
+)
+
+// planTopicCount is the number of lowest-scoring topics that go into a learning plan.
+const planTopicCount = 8
+
+type (
+	PlanTopic struct {
+		MainCategory string
+		Name         string
+		Description  string
+		Score        int
+		Uncertainty  int
+		SelfScore    int
+		Comment      string
+	}
+
+	LearningPlan struct {
+		Summary string              `json:"summary"`
+		Goals   []string            `json:"goals"`
+		Topics  []LearningPlanTopic `json:"topics"`
+	}
+
+	LearningPlanTopic struct {
+		Name      string   `json:"name"`
+		Why       string   `json:"why"`
+		Steps     []string `json:"steps"`
+		Exercises []string `json:"exercises"`
+	}
+)
+
+var (
+	planPrompt = template.Must(
+		template.New("plan").
+			Parse(`You are a mentor writing a personalised study plan for a software developer.
+The developer was interviewed on a range of topics and rated on this scale:
+{{- range .Rubric.Levels}}
+- {{.Value}}: {{.Name}}{{with .Description}} – {{.}}{{end}}
+{{- end}}
+
+These are their weakest topics, lowest score first:
+{{range .Topics}}
+- {{.Name}} ({{.MainCategory}}), score {{.Score}}
+  Scope: {{.Description}}
+  Interviewer feedback: {{if .Comment}}{{.Comment}}{{else}}none{{end}}
+{{- if .SelfScore}}
+  Self rating: {{.SelfScore}}
+{{- end}}
+{{end}}
+Write a plan that is concrete and actionable:
+• 2–4 overall goals for the next few months.
+• The topics in the order they should be studied. Put foundations before
+  topics that build on them, otherwise prefer the lowest scores first.
+  For each topic explain in one sentence why it matters, list 2–4 study
+  steps and 1–3 hands-on practice exercises.
+• Base everything on the interviewer feedback where it is given.
+• Do not invent topics that are not in the list.`))
+
+	planConfig = &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+		ResponseSchema: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"summary": {Type: genai.TypeString},
+				"goals": {
+					Type:  genai.TypeArray,
+					Items: &genai.Schema{Type: genai.TypeString},
+				},
+				"topics": {
+					Type: genai.TypeArray,
+					Items: &genai.Schema{
+						Type: genai.TypeObject,
+						Properties: map[string]*genai.Schema{
+							"name": {Type: genai.TypeString},
+							"why":  {Type: genai.TypeString},
+							"steps": {
+								Type:  genai.TypeArray,
+								Items: &genai.Schema{Type: genai.TypeString},
+							},
+							"exercises": {
+								Type:  genai.TypeArray,
+								Items: &genai.Schema{Type: genai.TypeString},
+							},
+						},
+						Required: []string{"name", "why", "steps", "exercises"},
+					},
+				},
+			},
+			Required: []string{"summary", "goals", "topics"},
+		},
+	}
+
+	planMarkdown = template.Must(
+		template.New("plan.md").
+			Funcs(template.FuncMap{
+				"inc":         func(i int) int { return i + 1 },
+				"format":      func(score, uncertainty int) string { return appConfig.Rubric.FormatUncertain(score, uncertainty) },
+				"calibration": func(self, assessed int) string { return appConfig.Rubric.Calibration(self, assessed) },
+			}).
+			Parse(`# Learning plan
+
+{{.Plan.Summary}}
+
+## Goals
+{{range .Plan.Goals}}
+- {{.}}{{end}}
+
+## Topics
+{{range $i, $t := .Plan.Topics}}
+### {{inc $i}}. {{$t.Name}}
+
+{{$t.Why}}
+
+Study steps:
+{{range $t.Steps}}
+- {{.}}{{end}}
+
+Practice exercises:
+{{range $t.Exercises}}
+- [ ] {{.}}{{end}}
+{{end}}
+## Current scores
+{{range .Topics}}
+- {{.Name}} ({{.MainCategory}}): {{format .Score .Uncertainty}}{{if .SelfScore}}, self {{.SelfScore}}{{with calibration .SelfScore .Score}} – {{.}}{{end}}{{end}}{{end}}
+`))
+)
+
+// getPlanLocation returns the path of the learning plan, stored next to the data file.
+func getPlanLocation() string {
+	return filepath.Join(getDataDir(), "plan.md")
+}
+
+// lowestScoringTopics returns up to n assessed sub categories, lowest score first.
+func lowestScoringTopics(n int) []PlanTopic {
+	var topics []PlanTopic
+	for _, mc := range Categories {
+		for _, sc := range mc.SubCategories {
+			if sc.Score <= 0 {
+				continue
+			}
+			topics = append(topics, PlanTopic{
+				MainCategory: mc.Name,
+				Name:         sc.Name,
+				Description:  sc.Description,
+				Score:        sc.Score,
+				Uncertainty:  sc.Uncertainty,
+				SelfScore:    sc.SelfScore,
+				Comment:      sc.Comment,
+			})
+		}
+	}
+	sort.SliceStable(topics, func(i, j int) bool {
+		return topics[i].Score < topics[j].Score
+	})
+	if len(topics) > n {
+		topics = topics[:n]
+	}
+	return topics
+}
+
+// Plan generates a learning plan from the weakest topics and writes it as Markdown next to the data file.
+func Plan() error {
+	topics := lowestScoringTopics(planTopicCount)
+	if len(topics) == 0 {
+		return errors.New("no assessed topics yet, run an interview first")
+	}
+
+	sb := strings.Builder{}
+	err := planPrompt.Execute(&sb, map[string]any{"Topics": topics, "Rubric": appConfig.Rubric})
+	if err != nil {
+		return errors.Join(errors.New("failed to execute plan prompt template"), err)
+	}
+
+	fmt.Println("Generating learning plan...")
+	resp, err := llmClient.Models.GenerateContent(
+		context.Background(),
+		appConfig.Model,
+		genai.Text(sb.String()),
+		planConfig,
+	)
+	if err != nil {
+		return errors.Join(errors.New("failed to generate content"), err)
+	}
+
+	plan := LearningPlan{}
+	if err := json.Unmarshal([]byte(resp.Text()), &plan); err != nil {
+		return errors.Join(errors.New("failed to unmarshal response"), err)
+	}
+
+	md := strings.Builder{}
+	err = planMarkdown.Execute(&md, map[string]any{"Plan": plan, "Topics": topics})
+	if err != nil {
+		return errors.Join(errors.New("failed to execute plan markdown template"), err)
+	}
+
+	name := getPlanLocation()
+	_ = os.MkdirAll(filepath.Dir(name), 0755)
+	if err := os.WriteFile(name, []byte(md.String()), 0644); err != nil {
+		return errors.Join(errors.New("failed to write plan"), err)
+	}
+	fmt.Printf("Learning plan written to %s\n", name)
+	return nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed prompts/*.tmpl
+var builtinPrompts embed.FS
+
+// LibraryPrompt is a named and versioned interview prompt.
+// Prompts are stored as <name>@<major>.<minor>.<patch>.tmpl, either built in or in the prompts directory.
+type LibraryPrompt struct {
+	Name    string
+	Version string
+	Hash    string
+	Source  string
+	BuiltIn bool
+}
+
+// ID returns the name@version identifier of the prompt.
+func (p LibraryPrompt) ID() string {
+	return p.Name + "@" + p.Version
+}
+
+// interviewPrompt is the library prompt selected by the config.
+var interviewPrompt LibraryPrompt
+
+// hashPrompt returns a short content hash of the given template sources.
+func hashPrompt(sources ...string) string {
+	h := sha256.New()
+	for _, src := range sources {
+		h.Write([]byte(src))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// parseVersion parses a semantic version of the form major.minor.patch.
+func parseVersion(v string) ([3]int, bool) {
+	var version [3]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return version, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+// parseVersionPrefix parses a full version or a prefix of one, e.g. "2" or "1.2".
+func parseVersionPrefix(v string) ([]int, bool) {
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return nil, false
+	}
+	prefix := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		prefix[i] = n
+	}
+	return prefix, true
+}
+
+// matchesVersion reports whether the version starts with the given prefix.
+func matchesVersion(version string, prefix []int) bool {
+	v, _ := parseVersion(version)
+	return slices.Equal(v[:len(prefix)], prefix)
+}
+
+func compareVersions(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] - vb[i]
+		}
+	}
+	return 0
+}
+
+// getPromptsDir returns the directory that holds user defined library prompts.
+func getPromptsDir() string {
+	return filepath.Join(getDataDir(), "prompts")
+}
+
+func readPrompts(fsys fs.FS, builtIn bool) ([]LibraryPrompt, error) {
+	names, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	prompts := make([]LibraryPrompt, 0, len(names))
+	for _, fileName := range names {
+		name, version, ok := strings.Cut(strings.TrimSuffix(fileName, ".tmpl"), "@")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("prompt %s is not named <name>@<version>.tmpl", fileName)
+		}
+		if _, ok := parseVersion(version); !ok {
+			return nil, fmt.Errorf("prompt %s has no semantic version", fileName)
+		}
+		data, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, LibraryPrompt{
+			Name:    name,
+			Version: version,
+			Hash:    hashPrompt(string(data)),
+			Source:  string(data),
+			BuiltIn: builtIn,
+		})
+	}
+	return prompts, nil
+}
+
+// PromptLibrary returns all built-in and user prompts sorted by name and version.
+// User prompts replace built-in prompts with the same name and version.
+func PromptLibrary() ([]LibraryPrompt, error) {
+	builtIn, err := fs.Sub(builtinPrompts, "prompts")
+	if err != nil {
+		return nil, err
+	}
+	library, err := readPrompts(builtIn, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(getPromptsDir()); err == nil {
+		userPrompts, err := readPrompts(os.DirFS(getPromptsDir()), false)
+		if err != nil {
+			return nil, err
+		}
+		for _, up := range userPrompts {
+			library = slices.DeleteFunc(library, func(p LibraryPrompt) bool { return p.ID() == up.ID() })
+			library = append(library, up)
+		}
+	}
+	sort.Slice(library, func(i, j int) bool {
+		if library[i].Name != library[j].Name {
+			return library[i].Name < library[j].Name
+		}
+		return compareVersions(library[i].Version, library[j].Version) < 0
+	})
+	return library, nil
+}
+
+// findPrompt resolves "name" to the latest version of a prompt, "name@version" to that exact version
+// and "name@1" or "name@1.2" to the latest version with that major or major.minor.
+func findPrompt(library []LibraryPrompt, selector string) (LibraryPrompt, error) {
+	name, version, pinned := strings.Cut(selector, "@")
+	prefix, isPrefix := parseVersionPrefix(version)
+	var found *LibraryPrompt
+	for i, p := range library {
+		if p.Name != name {
+			continue
+		}
+		if pinned && p.Version == version {
+			return p, nil
+		}
+		// the library is sorted by version, the last match is the latest
+		if !pinned || (isPrefix && matchesVersion(p.Version, prefix)) {
+			found = &library[i]
+		}
+	}
+	if found == nil {
+		return LibraryPrompt{}, fmt.Errorf("prompt %q not found in prompt library", selector)
+	}
+	return *found, nil
+}
+
+// LoadPrompt selects the interview prompt configured in the config.
+func LoadPrompt() {
+	library, err := PromptLibrary()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading prompt library: %v\n", err)
+		os.Exit(1)
+	}
+	interviewPrompt, err = findPrompt(library, appConfig.Prompt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error selecting prompt: %v\n", err)
+		os.Exit(1)
+	}
+	_, err = template.New("prompt").Parse(interviewPrompt.Source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing prompt %s: %v\n", interviewPrompt.ID(), err)
+		os.Exit(1)
+	}
+}
+
+// ListPrompts prints the prompt library and marks the selected prompt.
+func ListPrompts() error {
+	library, err := PromptLibrary()
+	if err != nil {
+		return err
+	}
+	for _, p := range library {
+		marker := " "
+		if p.ID() == interviewPrompt.ID() {
+			marker = "*"
+		}
+		origin := getPromptsDir()
+		if p.BuiltIn {
+			origin = "built-in"
+		}
+		fmt.Printf("%s %-24s %s  %s\n", marker, p.ID(), p.Hash, origin)
+	}
+	return nil
+}
+
+// PromptData is passed to the interview prompt template.
+type PromptData struct {
+	Topic           string // "Sub category (Main category)"
+	MainCategory    string
+	SubCategory     string
+	Description     string
+	PreviousScore   int // 0 if the topic was never assessed
+	PreviousComment string
+	SelfScore       int    // 0 if the candidate did not rate themself
+	Difficulty      string // foundational, intermediate, advanced or expert
+	Rubric          Rubric
+	Interview       InterviewConfig
+}
+
+var difficultyLevels = []string{"foundational", "intermediate", "advanced", "expert"}
+
+// startLevelForScore picks the index of the starting difficulty based on a previous score.
+// Topics that were never assessed start at intermediate level.
+func startLevelForScore(score int) int {
+	if score <= 0 {
+		return 1
+	}
+	i := int(appConfig.Rubric.Fraction(score) * float64(len(difficultyLevels)))
+	return clamp(0, i, len(difficultyLevels)-1)
+}
+
+func GetCurrentPromptData() PromptData {
+	data := promptDataFor(Categories[mainCategoryIndex], Categories[mainCategoryIndex].SubCategories[subCategoryIndex])
+	if staircase != nil {
+		data.Difficulty = difficultyLevels[staircase.Level]
+	}
+	if questionBank != nil && offlineBank == nil {
+		// the model asks every drawn question, the limits of the prompt must not contradict the bank note
+		data.Interview.MaxTechnicalQuestions = len(questionBank.Draw())
+		data.Interview.MaxTurns = turnLimit
+	}
+	return data
+}
+
+func promptDataFor(mc MainCategory, sc SubCategory) PromptData {
+	level := startLevelForScore(sc.Score)
+	return PromptData{
+		Topic:           fmt.Sprintf("%s (%s)", sc.Name, mc.Name),
+		MainCategory:    mc.Name,
+		SubCategory:     sc.Name,
+		Description:     sc.Description,
+		PreviousScore:   sc.Score,
+		PreviousComment: sc.Comment,
+		SelfScore:       sc.SelfScore,
+		Difficulty:      difficultyLevels[level],
+		Rubric:          appConfig.Rubric,
+		Interview:       appConfig.Interview,
+	}
+}
+
+// slug turns a category name into a file name friendly string ("Hashing, signatures" -> "hashing-signatures").
+func slug(name string) string {
+	sb := strings.Builder{}
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+// getTemplatesDir returns the directory that holds the per category prompt templates.
+func getTemplatesDir() string {
+	return filepath.Join(getDataDir(), "templates")
+}
+
+// getPromptTemplate returns the library prompt lp for a sub category
+// together with a hash over all template sources that went into it.
+//
+// Templates are looked up in the templates directory as <main>.tmpl and <main>/<sub>.tmpl
+// (slugged names) and applied in that order on top of the selected library prompt.
+// A file that only contains {{define "extra"}}...{{end}} extends the prompt,
+// any other top-level text replaces the prompt as a whole.
+func getPromptTemplate(lp LibraryPrompt, mc MainCategory, sc SubCategory) (*template.Template, string, error) {
+	tmpl, err := template.New("prompt").Parse(lp.Source)
+	if err != nil {
+		return nil, "", err
+	}
+	sources := []string{lp.Source}
+	dir := getTemplatesDir()
+	for _, name := range []string{
+		filepath.Join(dir, slug(mc.Name)+".tmpl"),
+		filepath.Join(dir, slug(mc.Name), slug(sc.Name)+".tmpl"),
+	} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, "", err
+		}
+		if _, err := tmpl.Parse(string(data)); err != nil {
+			return nil, "", errors.Join(errors.New("failed to parse "+name), err)
+		}
+		sources = append(sources, string(data))
+	}
+	return tmpl, hashPrompt(sources...), nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const assessmentKindRegrade = "regrade"
+
+// resolvePromptFlag turns the --prompt flag into a library selector.
+// "v1.2.0" and "1.2.0" select a version of the configured prompt, "v2" or "v1.2" its latest
+// version with that major or major.minor, anything else is used as is.
+func resolvePromptFlag(value string) string {
+	version := strings.TrimPrefix(value, "v")
+	if _, ok := parseVersionPrefix(version); ok {
+		name, _, _ := strings.Cut(appConfig.Prompt, "@")
+		return name + "@" + version
+	}
+	return value
+}
+
+// findCategory returns the current category definitions of a transcript,
+// falling back to bare names if the category no longer exists.
+func findCategory(mainName, subName string) (MainCategory, SubCategory) {
+	for _, mc := range Categories {
+		if mc.Name != mainName {
+			continue
+		}
+		for _, sc := range mc.SubCategories {
+			if sc.Name == subName {
+				// the previous score and self rating are not known for an old transcript
+				sc.Score = 0
+				sc.Comment = ""
+				sc.SelfScore = 0
+				return mc, sc
+			}
+		}
+	}
+	return MainCategory{Name: mainName}, SubCategory{Name: subName}
+}
+
+// matchesTopics reports whether the transcript belongs to one of the given topics.
+// Topics match the main or sub category name, an empty list matches everything.
+func matchesTopics(t Transcript, topics []string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		if slug(topic) == slug(t.SubCategory) || slug(topic) == slug(t.MainCategory) {
+			return true
+		}
+	}
+	return false
+}
+
+// regradeTranscript re-runs the rating step of the interview over a stored conversation.
+func regradeTranscript(ctx context.Context, model string, lp LibraryPrompt, t Transcript) (Assessment, error) {
+	mc, sc := findCategory(t.MainCategory, t.SubCategory)
+	tmpl, hash, err := getPromptTemplate(lp, mc, sc)
+	if err != nil {
+		return Assessment{}, errors.Join(errors.New("failed to load prompt template"), err)
+	}
+	sb := strings.Builder{}
+	if err := tmpl.Execute(&sb, promptDataFor(mc, sc)); err != nil {
+		return Assessment{}, errors.Join(errors.New("failed to execute prompt template"), err)
+	}
+	sb.WriteString(budgetExhaustedNote)
+
+	history, err := toContents(t.Entries)
+	if err != nil {
+		return Assessment{}, err
+	}
+	resp, err := llmClient.Models.GenerateContent(
+		ctx,
+		model,
+		history,
+		interviewConfig(sb.String(), true),
+	)
+	if err != nil {
+		return Assessment{}, errors.Join(errors.New("failed to generate content"), err)
+	}
+
+	aiResp := AiResponse{}
+	if err := json.Unmarshal([]byte(resp.Text()), &aiResp); err != nil {
+		return Assessment{}, errors.Join(errors.New("failed to unmarshal response"), err)
+	}
+	if aiResp.Rating == nil {
+		return Assessment{}, fmt.Errorf("%s did not return a rating", model)
+	}
+
+	grades := gradesOf(t.Entries)
+	confidence := ratingConfidence(aiResp.Confidence, grades)
+	a := Assessment{
+		Date:        time.Now(),
+		Kind:        assessmentKindRegrade,
+		Model:       model,
+		Prompt:      lp.ID(),
+		PromptHash:  hash,
+		Rubric:      appConfig.Rubric,
+		Rating:      appConfig.Rubric.Clamp(*aiResp.Rating),
+		Confidence:  confidence,
+		Uncertainty: ratingUncertainty(appConfig.Rubric, confidence, len(grades)),
+	}
+	if aiResp.Comment != nil {
+		a.Comment = *aiResp.Comment
+	}
+	return a, nil
+}
+
+// Regrade rates stored transcripts again without re-interviewing and stores the results as additional assessments.
+//
+//	profiler regrade [--model X] [--prompt vN] [topic...]
+func Regrade(args []string) error {
+	fs := flag.NewFlagSet("regrade", flag.ExitOnError)
+	model := fs.String("model", appConfig.Model, "model that rates the transcripts")
+	promptFlag := fs.String("prompt", appConfig.Prompt, "library prompt, name[@version] or a version of the configured prompt (v1.2.0, v1.2 or v1)")
+	_ = fs.Parse(args)
+
+	library, err := PromptLibrary()
+	if err != nil {
+		return err
+	}
+	lp, err := findPrompt(library, resolvePromptFlag(*promptFlag))
+	if err != nil {
+		return err
+	}
+
+	transcripts, err := ListTranscripts()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	regraded := 0
+	for _, t := range transcripts {
+		if !matchesTopics(t, fs.Args()) {
+			continue
+		}
+		a, err := regradeTranscript(ctx, *model, lp, t)
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to regrade %s", t.ID), err)
+		}
+		t.Assessments = append(t.Assessments, a)
+		if err := WriteTranscript(t); err != nil {
+			return errors.Join(fmt.Errorf("failed to save %s", t.ID), err)
+		}
+		regraded++
+		fmt.Printf("%s  %s: %s -> %s\n",
+			t.Date.Format(time.DateOnly),
+			t.Topic(),
+			t.Rubric.FormatUncertain(t.Rating, t.Uncertainty),
+			a.Rubric.FormatUncertain(a.Rating, a.Uncertainty),
+		)
+	}
+	if regraded == 0 {
+		return errors.New("no matching transcripts")
+	}
+	fmt.Printf("Regraded %d transcripts with %s and %s\n", regraded, *model, lp.ID())
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrintReport prints the scores of all categories with their uncertainty,
+// the self ratings and everything worth a second look.
+func PrintReport() {
+	for _, category := range Categories {
+		fmt.Printf("%s: %s\n", category.Name, appConfig.Rubric.FormatUncertain(category.Score(), category.Uncertainty()))
+		for _, subCategory := range category.SubCategories {
+			fmt.Printf("  %s: %s%s\n", subCategory.Name, appConfig.Rubric.FormatUncertain(subCategory.Score, subCategory.Uncertainty), reportNotes(subCategory))
+		}
+	}
+}
+
+// reportNotes returns the self rating and flags of a sub category for the report.
+func reportNotes(sc SubCategory) string {
+	var notes []string
+	if sc.SelfScore > 0 {
+		note := fmt.Sprintf("self %d", sc.SelfScore)
+		if calibration := appConfig.Rubric.Calibration(sc.SelfScore, sc.Score); calibration != "" {
+			note += ", " + calibration + " (!)"
+		}
+		notes = append(notes, note)
+	}
+	if sc.OriginalScore > 0 {
+		notes = append(notes, fmt.Sprintf("appealed, was %d", sc.OriginalScore))
+	}
+	if sc.Disagreement {
+		notes = append(notes, "panel disagrees, re-test")
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, "; ") + ")"
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// disputeTurns is the number of messages the model may send after a dispute before it has to rate again.
+const disputeTurns = 2
+
+// ResultMessage shows the rating of a topic before the interview moves on.
+type ResultMessage struct {
+	Topic       string
+	Score       int
+	Uncertainty int
+	Comment     string
+	Notes       string // self rating and flags, see reportNotes
+	// Disputable is false if the topic was graded offline, there is no model to argue with.
+	Disputable bool
+	// Appealable is false without a model and once the rating was appealed.
+	Appealable bool
+}
+
+// Content returns the result as Markdown for the conversation.
+func (msg ResultMessage) Content() string {
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "**%s: %s**", msg.Topic, appConfig.Rubric.FormatUncertain(msg.Score, msg.Uncertainty))
+	if msg.Notes != "" {
+		sb.WriteString(msg.Notes)
+	}
+	if msg.Comment != "" {
+		sb.WriteString("\n\n" + msg.Comment)
+	}
+	return sb.String()
+}
+
+// sendResult shows the rating of the current topic to the user.
+func sendResult() {
+	sc := Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	program.Send(ResultMessage{
+		Topic:       GetCurrentCategory(),
+		Score:       sc.Score,
+		Uncertainty: sc.Uncertainty,
+		Comment:     sc.Comment,
+		Notes:       reportNotes(sc),
+		Disputable:  offlineBank == nil,
+		Appealable:  llmClient != nil && sc.OriginalScore == 0,
+	})
+}
+
+// Accept keeps the rating of the current topic and moves on to the next one.
+func Accept() {
+	engine.Lock()
+	defer engine.Unlock()
+	Advance()
+}
+
+// Retake interviews the current topic again from the start.
+func Retake() {
+	engine.Lock()
+	defer engine.Unlock()
+	begin()
+}
+
+// Dispute continues the interview of the rated topic with an objection of the user.
+// The model gets a few more turns and has to rate again afterwards.
+func Dispute(userInput string) {
+	engine.Lock()
+	defer engine.Unlock()
+	sc := Categories[mainCategoryIndex].SubCategories[subCategoryIndex]
+	aiMessageHistory = append(aiMessageHistory, AiMessageHistoryEntry{
+		Content: fmt.Sprintf("My rating: %s\n\n%s", appConfig.Rubric.Format(sc.Score), sc.Comment),
+	})
+	turnLimit = askedQuestions() + disputeTurns
+	interview(userInput, false)
+}
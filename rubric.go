@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type (
+	// Rubric is the rating scale used by the interviewer.
+	// Scores are integers from Min to Max, 0 is reserved for "not assessed".
+	Rubric struct {
+		Name   string        `json:"name"`
+		Min    int           `json:"min"`
+		Max    int           `json:"max"`
+		Levels []RubricLevel `json:"levels"`
+	}
+
+	// RubricLevel is a named anchor on the rating scale.
+	RubricLevel struct {
+		Value       int    `json:"value"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+)
+
+var rubricPresets = map[string]Rubric{
+	"default": {
+		Name: "default",
+		Min:  1,
+		Max:  100,
+		Levels: []RubricLevel{
+			{Value: 100, Name: "Legend/mastery"},
+			{Value: 75, Name: "Highly proficient"},
+			{Value: 50, Name: "Competent mid-level"},
+			{Value: 25, Name: "Foundational knowledge with gaps"},
+			{Value: 10, Name: "Junior/basic theoretical"},
+			{Value: 1, Name: "No understanding/misconceptions"},
+		},
+	},
+	"dreyfus": {
+		Name: "dreyfus",
+		Min:  1,
+		Max:  5,
+		Levels: []RubricLevel{
+			{Value: 5, Name: "Expert", Description: "Works from deep intuition, sees the whole picture and teaches others."},
+			{Value: 4, Name: "Proficient", Description: "Understands the big picture, recognises deviations from normal patterns."},
+			{Value: 3, Name: "Competent", Description: "Plans deliberately and can solve common problems independently."},
+			{Value: 2, Name: "Advanced beginner", Description: "Applies rules in context but has limited situational understanding."},
+			{Value: 1, Name: "Novice", Description: "Knows some rules and facts but cannot apply them without guidance."},
+		},
+	},
+}
+
+// UnmarshalJSON accepts either the name of a preset ("default", "dreyfus") or a full rubric object.
+func (r *Rubric) UnmarshalJSON(data []byte) error {
+	var preset string
+	if err := json.Unmarshal(data, &preset); err == nil {
+		p, ok := rubricPresets[preset]
+		if !ok {
+			return fmt.Errorf("unknown rubric preset %q", preset)
+		}
+		*r = p
+		return nil
+	}
+
+	type rubric Rubric
+	var custom rubric
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return err
+	}
+	*r = Rubric(custom)
+	if r.Name == "" {
+		r.Name = "custom"
+	}
+	return r.normalize()
+}
+
+// normalize validates the rubric and sorts its levels from highest to lowest.
+func (r *Rubric) normalize() error {
+	if r.Min < 1 {
+		return errors.New("rubric min must be at least 1")
+	}
+	if r.Max <= r.Min {
+		return errors.New("rubric max must be greater than min")
+	}
+	if len(r.Levels) == 0 {
+		return errors.New("rubric needs at least one level")
+	}
+	for _, level := range r.Levels {
+		if level.Value < r.Min || level.Value > r.Max {
+			return fmt.Errorf("rubric level %q is outside of %d to %d", level.Name, r.Min, r.Max)
+		}
+	}
+	sort.SliceStable(r.Levels, func(i, j int) bool {
+		return r.Levels[i].Value > r.Levels[j].Value
+	})
+	return nil
+}
+
+// Clamp limits a score to the scale of the rubric.
+func (r Rubric) Clamp(score int) int {
+	return clamp(r.Min, score, r.Max)
+}
+
+// Level returns the highest level the score reaches.
+func (r Rubric) Level(score int) RubricLevel {
+	for _, level := range r.Levels {
+		if score >= level.Value {
+			return level
+		}
+	}
+	return r.Levels[len(r.Levels)-1]
+}
+
+// Format returns the score together with the name of its level, e.g. "3 (Competent)".
+func (r Rubric) Format(score int) string {
+	if score <= 0 {
+		return "not assessed"
+	}
+	return fmt.Sprintf("%d (%s)", score, r.Level(score).Name)
+}
+
+// FormatUncertain returns the score with its ± range and the name of its level, e.g. "62 ± 8 (Competent mid-level)".
+func (r Rubric) FormatUncertain(score, uncertainty int) string {
+	if score <= 0 || uncertainty <= 0 {
+		return r.Format(score)
+	}
+	return fmt.Sprintf("%d ± %d (%s)", score, uncertainty, r.Level(score).Name)
+}
+
+// Calibration describes how far a self rating is off the assessed score.
+// It returns "" if either is missing or they are within a fifth of the scale.
+func (r Rubric) Calibration(self, assessed int) string {
+	if self <= 0 || assessed <= 0 {
+		return ""
+	}
+	threshold := max((r.Max-r.Min)/5, 1)
+	switch diff := self - assessed; {
+	case diff > threshold:
+		return fmt.Sprintf("overestimated by %d", diff)
+	case diff < -threshold:
+		return fmt.Sprintf("underestimated by %d", -diff)
+	}
+	return ""
+}
+
+// Fraction maps a score to 0..1 on the scale of the rubric.
+func (r Rubric) Fraction(score int) float64 {
+	return float64(r.Clamp(score)-r.Min) / float64(r.Max-r.Min)
+}
+
+// Rescale maps a score on the scale of another rubric to the same fraction of this one, 0 stays "not assessed".
+func (r Rubric) Rescale(score int, from Rubric) int {
+	if score <= 0 {
+		return 0
+	}
+	return r.Min + int(math.Round(from.Fraction(score)*float64(r.Max-r.Min)))
+}
+
+// RescaleRange maps a ± range on the scale of another rubric to this one, keeping a non-zero range non-zero.
+func (r Rubric) RescaleRange(uncertainty int, from Rubric) int {
+	if uncertainty <= 0 {
+		return 0
+	}
+	return max(int(math.Round(float64(uncertainty)*float64(r.Max-r.Min)/float64(from.Max-from.Min))), 1)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRubricUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantName   string
+		wantLevels []int
+		wantErr    bool
+	}{
+		{"preset", `"dreyfus"`, "dreyfus", []int{5, 4, 3, 2, 1}, false},
+		{"unknown preset", `"stars"`, "", nil, true},
+		{"custom levels are sorted", `{"min": 1, "max": 10, "levels": [{"value": 1, "name": "low"}, {"value": 10, "name": "high"}, {"value": 5, "name": "mid"}]}`, "custom", []int{10, 5, 1}, false},
+		{"named custom", `{"name": "stars", "min": 1, "max": 3, "levels": [{"value": 1, "name": "one"}]}`, "stars", []int{1}, false},
+		{"min below 1", `{"min": 0, "max": 10, "levels": [{"value": 5, "name": "mid"}]}`, "", nil, true},
+		{"max not above min", `{"min": 5, "max": 5, "levels": [{"value": 5, "name": "mid"}]}`, "", nil, true},
+		{"no levels", `{"min": 1, "max": 5}`, "", nil, true},
+		{"level out of range", `{"min": 1, "max": 5, "levels": [{"value": 6, "name": "too high"}]}`, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Rubric
+			err := json.Unmarshal([]byte(tt.data), &r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var levels []int
+			for _, level := range r.Levels {
+				levels = append(levels, level.Value)
+			}
+			if r.Name != tt.wantName || !slices.Equal(levels, tt.wantLevels) {
+				t.Errorf("Unmarshal(%s) = %q %v, want %q %v", tt.data, r.Name, levels, tt.wantName, tt.wantLevels)
+			}
+		})
+	}
+}
+
+func TestRubricFormat(t *testing.T) {
+	r := rubricPresets["dreyfus"]
+	tests := []struct {
+		score int
+		want  string
+	}{
+		{0, "not assessed"},
+		{1, "1 (Novice)"},
+		{3, "3 (Competent)"},
+		{5, "5 (Expert)"},
+	}
+	for _, tt := range tests {
+		if got := r.Format(tt.score); got != tt.want {
+			t.Errorf("Format(%d) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestRubricRescale(t *testing.T) {
+	percent := rubricPresets["default"]
+	dreyfus := rubricPresets["dreyfus"]
+	tests := []struct {
+		name     string
+		to, from Rubric
+		score    int
+		want     int
+	}{
+		{"not assessed", dreyfus, percent, 0, 0},
+		{"bottom", dreyfus, percent, 1, 1},
+		{"top", dreyfus, percent, 100, 5},
+		{"percent to dreyfus", dreyfus, percent, 75, 4},
+		{"dreyfus to percent", percent, dreyfus, 3, 51},
+		{"out of range is clamped", dreyfus, percent, 150, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.to.Rescale(tt.score, tt.from); got != tt.want {
+				t.Errorf("Rescale(%d) = %d, want %d", tt.score, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubCategoryRescale(t *testing.T) {
+	dreyfus := rubricPresets["dreyfus"]
+	tests := []struct {
+		name   string
+		stored SubCategory
+		want   SubCategory
+		wantOK bool
+	}{
+		{
+			name:   "same rubric",
+			stored: SubCategory{Score: 3, Rubric: "dreyfus"},
+			want:   SubCategory{Score: 3, Rubric: "dreyfus"},
+			wantOK: true,
+		},
+		{
+			name:   "stored before rubrics were recorded",
+			stored: SubCategory{Score: 75, SelfScore: 100, Uncertainty: 10},
+			want:   SubCategory{Score: 4, SelfScore: 5, Uncertainty: 1, Rubric: "dreyfus"},
+			wantOK: true,
+		},
+		{
+			name:   "self score only from a survey",
+			stored: SubCategory{SelfScore: 3, Rubric: "dreyfus"},
+			want:   SubCategory{SelfScore: 3, Rubric: "dreyfus"},
+			wantOK: true,
+		},
+		{
+			name:   "other preset",
+			stored: SubCategory{Score: 1, OriginalScore: 50, Rubric: "default", Panel: []PanelRating{{Rating: 100}}},
+			want:   SubCategory{Score: 1, OriginalScore: 3, Rubric: "dreyfus", Panel: []PanelRating{{Rating: 5}}},
+			wantOK: true,
+		},
+		{
+			name:   "unknown custom rubric",
+			stored: SubCategory{Score: 7, Rubric: "stars"},
+			want:   SubCategory{Score: 7, Rubric: "stars"},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := tt.stored
+			ok := sc.rescale(dreyfus)
+			if ok != tt.wantOK || sc.Score != tt.want.Score || sc.SelfScore != tt.want.SelfScore ||
+				sc.OriginalScore != tt.want.OriginalScore || sc.Uncertainty != tt.want.Uncertainty ||
+				sc.Rubric != tt.want.Rubric || !slices.Equal(sc.Panel, tt.want.Panel) {
+				t.Errorf("rescale(%+v) = %+v, %v, want %+v, %v", tt.stored, sc, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
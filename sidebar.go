@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	sidebarWidth = 36
+	// sidebarCommentHeight is the number of lines below the tree for the comment of the selected topic
+	sidebarCommentHeight = 6
+)
+
+// JumpFailedMessage tells the user that the topic picked in the sidebar cannot be interviewed.
+type JumpFailedMessage struct {
+	Topic string
+	Err   error
+}
+
+// ScoresMessage carries the categories with their current scores after a rating.
+type ScoresMessage struct {
+	Categories []MainCategory
+}
+
+// sidebarItem is a line of the category tree, sub is -1 for a main category.
+type sidebarItem struct {
+	main int
+	sub  int
+}
+
+// sidebar lists all categories with their scores and lets the user jump to a topic.
+type sidebar struct {
+	open       bool
+	categories []MainCategory
+	items      []sidebarItem
+	cursor     int // index in items, always on a sub category
+	offset     int // first visible item
+	activeMain int
+	activeSub  int
+	height     int
+}
+
+func newSidebar(categories []MainCategory) sidebar {
+	s := sidebar{}
+	s.setCategories(categories)
+	return s
+}
+
+func (s *sidebar) setCategories(categories []MainCategory) {
+	s.categories = categories
+	s.items = nil
+	for i, mc := range categories {
+		s.items = append(s.items, sidebarItem{main: i, sub: -1})
+		for j := range mc.SubCategories {
+			s.items = append(s.items, sidebarItem{main: i, sub: j})
+		}
+	}
+	s.cursor = min(max(s.cursor, 1), len(s.items)-1)
+}
+
+// setActive marks the topic that is interviewed and moves the cursor onto it.
+func (s *sidebar) setActive(main, sub int) {
+	s.activeMain, s.activeSub = main, sub
+	for i, item := range s.items {
+		if item.main == main && item.sub == sub {
+			s.cursor = i
+		}
+	}
+	s.scroll()
+}
+
+// move moves the cursor by delta sub categories, skipping the main category lines.
+func (s *sidebar) move(delta int) {
+	for i := s.cursor + delta; i >= 0 && i < len(s.items); i += delta {
+		if s.items[i].sub >= 0 {
+			s.cursor = i
+			s.scroll()
+			return
+		}
+	}
+}
+
+// selected returns the sub category under the cursor.
+func (s sidebar) selected() sidebarItem {
+	return s.items[s.cursor]
+}
+
+// click selects the item on the given line of the tree, counted from its top.
+func (s *sidebar) click(line int) {
+	if i := s.offset + line; i >= 0 && i < len(s.items) && s.items[i].sub >= 0 {
+		s.cursor = i
+	}
+}
+
+// setHeight sets the number of lines of the sidebar.
+func (s *sidebar) setHeight(height int) {
+	s.height = height
+	s.scroll()
+}
+
+// treeHeight returns the number of lines of the tree above the comment.
+func (s *sidebar) treeHeight() int {
+	return max(s.height-sidebarCommentHeight-1, 1)
+}
+
+// scroll keeps the cursor within the visible lines of the tree.
+func (s *sidebar) scroll() {
+	lines := s.treeHeight()
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+lines {
+		s.offset = s.cursor - lines + 1
+	}
+	s.offset = max(min(s.offset, len(s.items)-lines), 0)
+}
+
+func (s sidebar) view() string {
+	r := appConfig.Rubric
+	width := sidebarWidth - 2 // border and padding
+	lines := s.treeHeight()
+
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	activeStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+
+	var tree []string
+	for i := s.offset; i < min(s.offset+lines, len(s.items)); i++ {
+		item := s.items[i]
+		mc := s.categories[item.main]
+		var name, score string
+		if item.sub < 0 {
+			name = mc.Name
+			if mc.Score() > 0 {
+				score = r.Format(mc.Score())
+			}
+			name = lipgloss.NewStyle().Bold(true).Render(truncate(name, width-len(score)-1))
+		} else {
+			sc := mc.SubCategories[item.sub]
+			name = " " + sc.Name
+			score = "–"
+			if sc.Score > 0 {
+				score = r.Format(sc.Score)
+			}
+			name = truncate(name, width-len(score)-1)
+		}
+		gap := max(width-lipgloss.Width(name)-lipgloss.Width(score), 1)
+		line := name + strings.Repeat(" ", gap) + score
+		switch {
+		case i == s.cursor:
+			line = cursorStyle.Render(line)
+		case item.main == s.activeMain && item.sub == s.activeSub:
+			line = activeStyle.Render(line)
+		}
+		tree = append(tree, line)
+	}
+
+	item := s.selected()
+	sc := s.categories[item.main].SubCategories[item.sub]
+	comment := sc.Comment
+	if sc.Score <= 0 {
+		comment = "Not assessed yet."
+	} else if sc.SelfScore > 0 {
+		comment = fmt.Sprintf("Self rating %s. %s", r.Format(sc.SelfScore), comment)
+	}
+	commentView := dimStyle.Width(width).MaxHeight(sidebarCommentHeight).Render(comment)
+
+	content := lipgloss.NewStyle().Height(lines).Render(strings.Join(tree, "\n")) + "\n\n" + commentView
+	return lipgloss.NewStyle().
+		Width(sidebarWidth-1).
+		Height(s.height).
+		MaxHeight(s.height).
+		Border(lipgloss.NormalBorder(), false, true, false, false).
+		BorderForeground(theme.Dim).
+		PaddingRight(1).
+		Render(content)
+}
+
+func (s sidebar) help() string {
+	return helpView(keys.Up, keys.Down, keys.Select, either(keys.Close, keys.Topics), keys.Quit)
+}
+
+// truncate shortens text to width cells with an ellipsis.
+func truncate(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// surveyItem points to a sub category in Categories.
+type surveyItem struct {
+	main int
+	sub  int
+}
+
+// surveyKeys are the fixed key bindings of the survey.
+var surveyKeys = struct {
+	Decrease, Increase, Save, Skip, Back, Quit key.Binding
+}{
+	Decrease: key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "adjust down")),
+	Increase: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "adjust up")),
+	Save:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("Enter", "save and next")),
+	Skip:     key.NewBinding(key.WithKeys("s", "down", "tab"), key.WithHelp("s/↓", "skip")),
+	Back:     key.NewBinding(key.WithKeys("up", "shift+tab"), key.WithHelp("↑", "back")),
+	Quit:     key.NewBinding(key.WithKeys("ctrl+c", "esc", "q"), key.WithHelp("q/Esc", "quit")),
+}
+
+// surveyModel walks all categories as a form and stores self ratings without an LLM.
+type surveyModel struct {
+	items  []surveyItem
+	index  int
+	value  int    // current value on the slider
+	input  string // digits typed by the user, overrides value while not empty
+	width  int
+	done   bool
+	notice string
+}
+
+func newSurveyModel() surveyModel {
+	m := surveyModel{width: 80}
+	for i, mc := range Categories {
+		for j := range mc.SubCategories {
+			m.items = append(m.items, surveyItem{main: i, sub: j})
+		}
+	}
+	m.load()
+	return m
+}
+
+func (m *surveyModel) current() *SubCategory {
+	item := m.items[m.index]
+	return &Categories[item.main].SubCategories[item.sub]
+}
+
+// load puts the slider on the stored self rating of the current item, or the middle of the scale.
+func (m *surveyModel) load() {
+	m.input = ""
+	m.notice = ""
+	m.value = m.current().SelfScore
+	if m.value <= 0 {
+		m.value = (appConfig.Rubric.Min + appConfig.Rubric.Max) / 2
+	}
+}
+
+// step is how far the arrow keys move the slider, about a twentieth of the scale.
+func (m surveyModel) step() int {
+	return max(1, int(math.Round(float64(appConfig.Rubric.Max-appConfig.Rubric.Min)/20)))
+}
+
+// next moves to the next item, the survey is done after the last one.
+func (m *surveyModel) next() {
+	if m.index+1 >= len(m.items) {
+		m.done = true
+		return
+	}
+	m.index++
+	m.load()
+}
+
+func (m surveyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m surveyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, surveyKeys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, surveyKeys.Decrease):
+			m.input = ""
+			m.value = appConfig.Rubric.Clamp(m.value - m.step())
+
+		case key.Matches(msg, surveyKeys.Increase):
+			m.input = ""
+			m.value = appConfig.Rubric.Clamp(m.value + m.step())
+
+		case msg.Type == tea.KeyBackspace:
+			if m.input != "" {
+				m.input = m.input[:len(m.input)-1]
+			}
+
+		case key.Matches(msg, surveyKeys.Save):
+			value := m.value
+			if m.input != "" {
+				n, err := strconv.Atoi(m.input)
+				if err != nil || n < appConfig.Rubric.Min || n > appConfig.Rubric.Max {
+					m.notice = fmt.Sprintf("enter a number from %d to %d", appConfig.Rubric.Min, appConfig.Rubric.Max)
+					return m, nil
+				}
+				value = n
+			}
+			m.current().setSelfScore(value)
+			SaveScores()
+			m.next()
+			if m.done {
+				return m, tea.Quit
+			}
+
+		case key.Matches(msg, surveyKeys.Skip):
+			m.next()
+			if m.done {
+				return m, tea.Quit
+			}
+
+		case key.Matches(msg, surveyKeys.Back):
+			if m.index > 0 {
+				m.index--
+				m.load()
+			}
+
+		default:
+			if len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9' {
+				m.input += string(msg.Runes)
+				m.notice = ""
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m surveyModel) View() string {
+	item := m.items[m.index]
+	mc := Categories[item.main]
+	sc := mc.SubCategories[item.sub]
+	r := appConfig.Rubric
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	descriptionStyle := lipgloss.NewStyle().Width(min(m.width, 80))
+
+	value := m.value
+	valueText := r.Format(value)
+	if m.input != "" {
+		n, _ := strconv.Atoi(m.input)
+		value = r.Clamp(n)
+		valueText = m.input + "_"
+	}
+
+	barWidth := min(max(m.width-20, 10), 50)
+	filled := int(math.Round(r.Fraction(value) * float64(barWidth)))
+	bar := strings.Repeat("■", filled) + dimStyle.Render(strings.Repeat("□", barWidth-filled))
+
+	helpView := helpView(surveyKeys.Decrease, surveyKeys.Increase, helpOnly("0-9", "type a number"),
+		surveyKeys.Save, surveyKeys.Skip, surveyKeys.Back, surveyKeys.Quit)
+	if m.notice != "" {
+		helpView = lipgloss.NewStyle().Foreground(theme.Error).Render(m.notice)
+	}
+
+	return fmt.Sprintf(
+		"%s\n\n%s\n%s\n\n%s\n\n%s  %s\n\n%s\n",
+		dimStyle.Render(fmt.Sprintf("Self-rating survey %d/%d", m.index+1, len(m.items))),
+		dimStyle.Render(mc.Name),
+		titleStyle.Render(sc.Name),
+		descriptionStyle.Render(sc.Description),
+		bar,
+		valueText,
+		helpView,
+	)
+}
+
+// Survey lets the user rate themself on every sub category without talking to a model.
+func Survey() error {
+	if _, err := tea.NewProgram(newSurveyModel(), tea.WithAltScreen()).Run(); err != nil {
+		return err
+	}
+	for _, category := range Categories {
+		fmt.Println(category.Name)
+		for _, subCategory := range category.SubCategories {
+			self := "skipped"
+			if subCategory.SelfScore > 0 {
+				self = appConfig.Rubric.Format(subCategory.SelfScore)
+			}
+			fmt.Printf("  %s: %s\n", subCategory.Name, self)
+		}
+	}
+	return nil
+}
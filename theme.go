@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultThemeName = "auto"
+
+// Theme holds the colours of the TUI and the style of the rendered Markdown.
+type Theme struct {
+	// Base is the preset a custom theme file starts from, "auto" if empty.
+	Base string `json:"base,omitempty"`
+	// Markdown is a glamour style name ("dark", "light", "notty", "dracula", ...) or the path of a glamour style file.
+	Markdown    string         `json:"markdown"`
+	Dim         lipgloss.Color `json:"dim"`         // help, borders and secondary text
+	Placeholder lipgloss.Color `json:"placeholder"` // placeholder of the input
+	CursorLine  lipgloss.Color `json:"cursor_line"` // background of the line of the cursor in the input
+	Accent      lipgloss.Color `json:"accent"`      // active topic and progress bar
+	Error       lipgloss.Color `json:"error"`       // notices about invalid input
+}
+
+var themePresets = map[string]Theme{
+	"dark": {
+		Markdown:    "dark",
+		Dim:         "8",
+		Placeholder: "7",
+		CursorLine:  "0",
+		Accent:      "4",
+		Error:       "1",
+	},
+	"light": {
+		Markdown:    "light",
+		Dim:         "8",
+		Placeholder: "8",
+		CursorLine:  "255",
+		Accent:      "4",
+		Error:       "1",
+	},
+	"high-contrast": {
+		Markdown:    "notty",
+		Dim:         "15",
+		Placeholder: "15",
+		CursorLine:  "0",
+		Accent:      "11",
+		Error:       "9",
+	},
+}
+
+// theme is the theme of the TUI, LoadTheme replaces it with the configured one.
+var theme = themePresets["dark"]
+
+// resolveTheme returns the preset with the given name, "auto" picks dark or light from the background of the terminal.
+func resolveTheme(name string) (Theme, error) {
+	if name == "" || name == defaultThemeName {
+		if lipgloss.HasDarkBackground() {
+			return themePresets["dark"], nil
+		}
+		return themePresets["light"], nil
+	}
+	if preset, ok := themePresets[name]; ok {
+		return preset, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q", name)
+}
+
+// readThemeFile reads a custom theme, fields it leaves out are taken from its base preset.
+func readThemeFile(name string) (Theme, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return Theme{}, err
+	}
+	var custom Theme
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return Theme{}, err
+	}
+	t, err := resolveTheme(custom.Base)
+	if err != nil {
+		return Theme{}, err
+	}
+	if custom.Markdown != "" {
+		t.Markdown = custom.Markdown
+		// style files are relative to the theme file
+		if _, ok := themePresets[t.Markdown]; !ok && filepath.Ext(t.Markdown) == ".json" && !filepath.IsAbs(t.Markdown) {
+			t.Markdown = filepath.Join(filepath.Dir(name), t.Markdown)
+		}
+	}
+	for _, c := range []struct{ dst, src *lipgloss.Color }{
+		{&t.Dim, &custom.Dim},
+		{&t.Placeholder, &custom.Placeholder},
+		{&t.CursorLine, &custom.CursorLine},
+		{&t.Accent, &custom.Accent},
+		{&t.Error, &custom.Error},
+	} {
+		if *c.src != "" {
+			*c.dst = *c.src
+		}
+	}
+	return t, nil
+}
+
+// LoadTheme applies the configured theme, either a preset name or the path of a theme file
+// relative to the data directory. It has to run before the TUI starts to query the terminal.
+func LoadTheme() {
+	name := appConfig.Theme
+	var err error
+	if filepath.Ext(name) == ".json" {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(getDataDir(), name)
+		}
+		theme, err = readThemeFile(name)
+	} else {
+		theme, err = resolveTheme(name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading theme %q: %v\n", appConfig.Theme, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Transcript is the persisted conversation of one interview together with its result.
+type Transcript struct {
+	ID           string                  `json:"id"`
+	Date         time.Time               `json:"date"`
+	MainCategory string                  `json:"main_category"`
+	SubCategory  string                  `json:"sub_category"`
+	Prompt       string                  `json:"prompt"`
+	PromptHash   string                  `json:"prompt_hash"`
+	Model        string                  `json:"model"`
+	Rubric       Rubric                  `json:"rubric"`
+	Entries      []AiMessageHistoryEntry `json:"entries"`
+	Rating       int                     `json:"rating"`
+	Comment      string                  `json:"comment"`
+	Confidence   float64                 `json:"confidence"`
+	Uncertainty  int                     `json:"uncertainty"`
+	Panel        []PanelRating           `json:"panel,omitempty"`
+	Disagreement bool                    `json:"disagreement,omitempty"`
+	// Assessments are later ratings of the same conversation, e.g. by regrade.
+	Assessments []Assessment `json:"assessments,omitempty"`
+}
+
+// Assessment is a rating of a stored transcript that was made after the interview.
+type Assessment struct {
+	Date        time.Time `json:"date"`
+	Kind        string    `json:"kind"`
+	Model       string    `json:"model"`
+	Prompt      string    `json:"prompt"`
+	PromptHash  string    `json:"prompt_hash"`
+	Rubric      Rubric    `json:"rubric"`
+	Rating      int       `json:"rating"`
+	Comment     string    `json:"comment"`
+	Confidence  float64   `json:"confidence"`
+	Uncertainty int       `json:"uncertainty"`
+	// Panel holds the independent ratings if the assessment was made by the grading panel.
+	Panel        []PanelRating `json:"panel,omitempty"`
+	Disagreement bool          `json:"disagreement,omitempty"`
+	// Original and Appeal are the rating before an appeal and the argument of the user.
+	Original int    `json:"original,omitempty"`
+	Appeal   string `json:"appeal,omitempty"`
+}
+
+// getTranscriptsDir returns the directory that holds one JSON file per interview.
+func getTranscriptsDir() string {
+	return filepath.Join(getDataDir(), "transcripts")
+}
+
+func getTranscriptLocation(id string) string {
+	return filepath.Join(getTranscriptsDir(), id+".json")
+}
+
+// NewTranscript returns the conversation of the current sub category with its final rating.
+func NewTranscript(rating int) Transcript {
+	mc := Categories[mainCategoryIndex]
+	sc := mc.SubCategories[subCategoryIndex]
+	now := time.Now()
+	return Transcript{
+		ID:           now.Format("20060102-150405") + "-" + slug(mc.Name) + "-" + slug(sc.Name),
+		Date:         now,
+		MainCategory: mc.Name,
+		SubCategory:  sc.Name,
+		Prompt:       sc.Prompt,
+		PromptHash:   sc.PromptHash,
+		Model:        sc.Model,
+		Rubric:       appConfig.Rubric,
+		Entries:      slices.Clone(aiMessageHistory),
+		Rating:       appConfig.Rubric.Clamp(rating),
+		Comment:      sc.Comment,
+		Confidence:   sc.Confidence,
+		Uncertainty:  sc.Uncertainty,
+		Panel:        sc.Panel,
+		Disagreement: sc.Disagreement,
+	}
+}
+
+// SaveTranscript stores the transcript and links it from the current sub category.
+func SaveTranscript(t Transcript) error {
+	if err := WriteTranscript(t); err != nil {
+		return err
+	}
+	Categories[mainCategoryIndex].SubCategories[subCategoryIndex].Transcript = t.ID
+	return nil
+}
+
+// Topic returns the topic of the transcript as "Sub category (Main category)".
+func (t Transcript) Topic() string {
+	return fmt.Sprintf("%s (%s)", t.SubCategory, t.MainCategory)
+}
+
+// Conversation returns the visible part of the interview as plain text.
+func (t Transcript) Conversation() string {
+	sb := strings.Builder{}
+	for _, entry := range t.Entries {
+		if entry.Hidden {
+			continue
+		}
+		if entry.IsUser {
+			sb.WriteString("Candidate: ")
+		} else {
+			sb.WriteString("Interviewer: ")
+		}
+		sb.WriteString(entry.Content)
+		for i, option := range entry.Options {
+			sb.WriteString(fmt.Sprintf("\n%s) %s", optionLetter(i), option))
+		}
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// Markdown returns the transcript with its result as a Markdown document.
+func (t Transcript) Markdown() string {
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "# %s\n\n", t.Topic())
+	meta := []string{t.Date.Format("2006-01-02 15:04")}
+	for _, s := range []string{t.Model, t.Prompt} {
+		if s != "" {
+			meta = append(meta, s)
+		}
+	}
+	fmt.Fprintf(&sb, "%s\n\n", strings.Join(meta, " · "))
+	fmt.Fprintf(&sb, "**Rating: %s**\n\n", t.Rubric.FormatUncertain(t.Rating, t.Uncertainty))
+	if t.Comment != "" {
+		fmt.Fprintf(&sb, "%s\n\n", t.Comment)
+	}
+	for _, a := range t.Assessments {
+		fmt.Fprintf(&sb, "- %s %s by %s: %s", a.Date.Format("2006-01-02"), a.Kind, a.Model, a.Rubric.Format(a.Rating))
+		if a.Appeal != "" {
+			fmt.Fprintf(&sb, " (was %d, appeal: “%s”)", a.Original, a.Appeal)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n---\n\n")
+	for _, entry := range t.Entries {
+		if entry.Hidden {
+			continue
+		}
+		if entry.IsUser {
+			sb.WriteString("**Candidate:**\n\n")
+		} else {
+			sb.WriteString("**Interviewer:**\n\n")
+		}
+		sb.WriteString(entry.Content)
+		for i, option := range entry.Options {
+			fmt.Fprintf(&sb, "\n- %s) %s", optionLetter(i), option)
+		}
+		sb.WriteString("\n\n")
+		if entry.Grade != nil {
+			// shows which answers moved the rating
+			fmt.Fprintf(&sb, "*%s*\n\n", entry.Grade)
+			for _, m := range entry.Grade.Misconceptions {
+				fmt.Fprintf(&sb, "- misconception: %s\n", m)
+			}
+			if len(entry.Grade.Misconceptions) > 0 {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func WriteTranscript(t Transcript) error {
+	name := getTranscriptLocation(t.ID)
+	_ = os.MkdirAll(filepath.Dir(name), 0755)
+	jsonData, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, jsonData, 0644)
+}
+
+func ReadTranscript(id string) (Transcript, error) {
+	var t Transcript
+	data, err := os.ReadFile(getTranscriptLocation(id))
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
+
+// ListTranscripts returns all stored transcripts, oldest first.
+func ListTranscripts() ([]Transcript, error) {
+	files, err := filepath.Glob(filepath.Join(getTranscriptsDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	transcripts := make([]Transcript, 0, len(files))
+	for _, file := range files {
+		t, err := ReadTranscript(strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to read transcript %s", file), err)
+		}
+		transcripts = append(transcripts, t)
+	}
+	slices.SortFunc(transcripts, func(a, b Transcript) int {
+		return a.Date.Compare(b.Date)
+	})
+	return transcripts, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranscriptMarkdownGrades(t *testing.T) {
+	tr := Transcript{
+		MainCategory: "Computer Science",
+		SubCategory:  "Algorithms",
+		Rubric:       rubricPresets["dreyfus"],
+		Rating:       3,
+		Entries: []AiMessageHistoryEntry{
+			{Content: "What backs a priority queue?"},
+			{Content: "A sorted list.", IsUser: true, Grade: &AnswerGrade{Correctness: 2, Depth: 1, Misconceptions: []string{"a sorted list is the usual implementation"}}},
+			{Content: "And its insert complexity?"},
+			{Content: "O(log n) with a heap.", IsUser: true, Grade: &AnswerGrade{Correctness: 5, Depth: 4}},
+		},
+	}
+	md := tr.Markdown()
+	for _, want := range []string{
+		"A sorted list.\n\n*correctness 2/5 · depth 1/5*\n\n- misconception: a sorted list is the usual implementation\n",
+		"O(log n) with a heap.\n\n*correctness 5/5 · depth 4/5*\n\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() is missing %q in\n%s", want, md)
+		}
+	}
+	if strings.Count(md, "correctness") != 2 {
+		t.Errorf("Markdown() shows grades for questions of the interviewer:\n%s", md)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/agnivade/levenshtein"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,9 +20,11 @@ import (
 
 var quitErr error
 
+const defaultPlaceholder = "Type your message..."
+
 func Err(err error) {
 	quitErr = err
-	teaProgram.Quit()
+	program.Quit()
 }
 
 type Message struct {
@@ -34,20 +39,37 @@ type model struct {
 	width            int
 	height           int
 	markdownRenderer *glamour.TermRenderer
+	header           header
+	sidebar          sidebar
+	thinking         bool // Set while the engine waits for the model
 
 	history      [][]byte // Compressed snapshots
 	historyIndex int      // Current position in history
 	historySize  int      // Current number of items in history
 	maxHistory   int      // Maximum history size (50)
 	lastSnapshot string   // Last saved snapshot to avoid duplicates
+
+	selfAssessment *SelfAssessmentMessage // Set while the user is asked for a self rating
+	challenge      *ChallengeMessage      // Set while the user works on a coding exercise
+	choice         *choice                // Set while a choice question replaces the textarea
+	result         *ResultMessage         // Set while the rating of a topic is shown
+	disputing      bool                   // Set while the user writes an objection to the rating
+	appealing      bool                   // Set while the user writes an appeal against the rating
+	browser        *browser               // Set while the transcript browser is open
+	selecting      bool                   // Set while the user selects a message to copy
+	selected       int                    // Index of the selected message in messages
+	messageLines   []int                  // First line of each message in the viewport
+	status         string                 // Shown instead of the help text until the next key, e.g. after an export
+	notice         string                 // Shown instead of the help text, e.g. for invalid input
 }
 
 func initialModel() model {
 	ta := textarea.New()
-	ta.Placeholder = "Type your message..."
-	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
-	ta.FocusedStyle.CursorLine = lipgloss.NewStyle().Background(lipgloss.Color("0"))
-	ta.BlurredStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	ta.Placeholder = defaultPlaceholder
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(theme.Placeholder)
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle().Background(theme.CursorLine)
+	ta.BlurredStyle.Text = lipgloss.NewStyle().Foreground(theme.Dim)
+	ta.KeyMap.InsertNewline = keys.NewLine
 	ta.Focus()
 	ta.CharLimit = 0
 	ta.SetWidth(80)
@@ -56,7 +78,7 @@ func initialModel() model {
 	vp := viewport.New(80, 15)
 
 	renderer, _ := glamour.NewTermRenderer(
-		glamour.WithStylePath("dark"),
+		glamour.WithStylePath(theme.Markdown),
 		glamour.WithWordWrap(40),
 	)
 
@@ -67,6 +89,8 @@ func initialModel() model {
 		width:            80,
 		height:           24,
 		markdownRenderer: renderer,
+		header:           newHeader(),
+		sidebar:          newSidebar(snapshotCategories()),
 		history:          make([][]byte, 50),
 		historyIndex:     -1,
 		historySize:      0,
@@ -86,6 +110,23 @@ func (m model) Init() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if m.browser != nil {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			updated, cmd := m.browser.Update(msg)
+			b := updated.(browser)
+			m.browser = &b
+			if b.closed {
+				m.browser = nil
+			}
+			return m, cmd
+		case tea.WindowSizeMsg:
+			updated, _ := m.browser.Update(msg)
+			b := updated.(browser)
+			m.browser = &b
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -93,19 +134,141 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.textarea.SetWidth(msg.Width - 4)
 
 		// Update viewport size
-		inputHeight := m.textarea.Height() + 4 // +4 for borders and help
-		m.viewport.Width = msg.Width
-		m.viewport.Height = msg.Height - inputHeight
+		m.layout()
 		m.viewport.GotoBottom()
 		m.updateViewport()
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		m.status = ""
+		if m.sidebar.open {
+			return m.updateSidebar(msg)
+		}
+		if m.selecting {
+			return m.updateSelection(msg)
+		}
+		if key.Matches(msg, keys.SelectMessage) {
+			if len(m.messages) == 0 {
+				return m, nil
+			}
+			m.selecting = true
+			m.selected = len(m.messages) - 1
+			m.notice = ""
+			m.updateViewport()
+			return m, nil
+		}
+		if key.Matches(msg, keys.Export) {
+			name, err := exportConversation(m.header.topic, m.messages)
+			if err != nil {
+				m.notice = "export failed: " + err.Error()
+				return m, nil
+			}
+			m.notice = ""
+			m.status = "exported to " + name
+			return m, nil
+		}
+		if key.Matches(msg, keys.Transcripts) {
+			b, err := newBrowser(m.width, m.height)
+			if err != nil {
+				m.notice = "failed to list transcripts: " + err.Error()
+				return m, nil
+			}
+			m.browser = &b
+			return m, nil
+		}
+		if key.Matches(msg, keys.Topics) {
+			m.sidebar.open = true
+			m.notice = ""
+			m.layout()
+			m.updateViewport()
+			return m, nil
+		}
+		if m.result != nil {
+			return m.updateResult(msg)
+		}
+		if m.choice != nil {
+			return m.updateChoice(msg)
+		}
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
 
-		case "ctrl+y":
+		case key.Matches(msg, keys.Send):
 			trimmedMessage := strings.TrimSpace(m.textarea.Value())
+			if m.selfAssessment != nil {
+				score, err := parseSelfScore(trimmedMessage, *m.selfAssessment)
+				if err != nil {
+					m.notice = err.Error()
+					return m, nil
+				}
+				content := "Skipped"
+				if score > 0 {
+					content = strconv.Itoa(score)
+				}
+				m.messages = append(m.messages, Message{
+					Content: content,
+					IsUser:  true,
+				})
+				m.selfAssessment = nil
+				m.notice = ""
+				m.textarea.Placeholder = defaultPlaceholder
+				m.textarea.Reset()
+				m.clearHistory()
+				m.updateViewport()
+
+				go SubmitSelfAssessment(score)
+				return m, nil
+			}
+			if m.challenge != nil {
+				if trimmedMessage == "" {
+					m.notice = "paste or write your solution first"
+					return m, nil
+				}
+				code := m.textarea.Value()
+				m.challenge = nil
+				m.notice = ""
+				m.textarea.Reset()
+				m.clearHistory()
+				go SubmitChallenge(code)
+				return m, nil
+			}
+			if m.appealing {
+				if trimmedMessage == "" {
+					m.notice = "write down why the rating should change first"
+					return m, nil
+				}
+				m.appealing = false
+				m.notice = ""
+				m.textarea.Placeholder = defaultPlaceholder
+				m.textarea.Reset()
+				m.clearHistory()
+				m.messages = append(m.messages, Message{
+					Content: "**Appeal:** " + trimmedMessage,
+					IsUser:  true,
+				})
+				m.updateViewport()
+
+				go Appeal(trimmedMessage)
+				return m, nil
+			}
+			if m.disputing {
+				if trimmedMessage == "" {
+					m.notice = "explain why you disagree with the rating first"
+					return m, nil
+				}
+				m.disputing = false
+				m.notice = ""
+				m.textarea.Placeholder = defaultPlaceholder
+				m.textarea.Reset()
+				m.clearHistory()
+				m.messages = append(m.messages, Message{
+					Content: trimmedMessage,
+					IsUser:  true,
+				})
+				m.updateViewport()
+
+				go Dispute(trimmedMessage)
+				return m, nil
+			}
 			if trimmedMessage != "" {
 				m.textarea.Reset()
 				m.clearHistory()
@@ -122,11 +285,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "ctrl+z": // Undo
+		case key.Matches(msg, keys.Editor): // Open the exercise or the answer in the editor
+			if m.challenge != nil {
+				content := m.textarea.Value()
+				if strings.TrimSpace(content) == "" {
+					content = m.challenge.Starter
+				}
+				return m, openEditor("challenge", content, "challenge-*"+filepath.Ext(m.challenge.Challenge.File))
+			}
+			if m.thinking {
+				return m, nil
+			}
+			return m, openEditor("answer", m.textarea.Value(), "answer-*.md")
+
+		case key.Matches(msg, keys.Undo):
 			m.undo()
 			return m, nil
 
-		case "ctrl+r": // Redo
+		case key.Matches(msg, keys.Redo):
 			m.redo()
 			return m, nil
 
@@ -144,21 +320,126 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case SelfAssessmentMessage:
+		m.selfAssessment = &msg
+		m.notice = ""
+		m.messages = append(m.messages, Message{
+			Content: fmt.Sprintf(
+				"Before we start: how would you rate your own expertise in **%s**?\n\nEnter a number from %d to %d, or send an empty message to skip.",
+				msg.Topic, msg.Min, msg.Max,
+			),
+			IsUser: false,
+		})
+		m.textarea.Placeholder = fmt.Sprintf("Your self rating (%d–%d)...", msg.Min, msg.Max)
+		m.textarea.Focus()
+		m.updateViewport()
+
+	case ChallengeMessage:
+		m.challenge = &msg
+		m.notice = ""
+		m.messages = append(m.messages, Message{
+			Content: fmt.Sprintf(
+				"**Exercise: %s**\n\n%s\n\nWrite your solution to `%s`. %s opens it in your editor, or paste it here and send with %s.",
+				msg.Challenge.Title, msg.Challenge.Task, msg.Challenge.File, keys.Editor.Help().Key, keys.Send.Help().Key,
+			),
+			IsUser: false,
+		})
+		m.updateViewport()
+		if editorCommand() != "" {
+			return m, openEditor("challenge", msg.Starter, "challenge-*"+filepath.Ext(msg.Challenge.File))
+		}
+		m.textarea.SetValue(msg.Starter)
+
+	case ChallengeResultMessage:
+		m.messages = append(m.messages, Message{
+			Content: msg.Content,
+			IsUser:  true,
+		})
+		m.updateViewport()
+
+	case EditorFinishedMessage:
+		if msg.Purpose == "answer" {
+			if msg.Err != nil {
+				m.notice = "editor failed: " + msg.Err.Error()
+				return m, nil
+			}
+			m.notice = ""
+			m.textarea.SetValue(strings.TrimRight(msg.Content, "\n"))
+			m.saveSnapshot()
+			m.textarea.Focus()
+		}
+		if msg.Purpose == "challenge" && m.challenge != nil {
+			if msg.Err != nil {
+				m.notice = "editor failed: " + msg.Err.Error()
+				return m, nil
+			}
+			if strings.TrimSpace(msg.Content) == "" {
+				m.notice = "the solution is empty, press " + keys.Editor.Help().Key + " to open the editor again"
+				return m, nil
+			}
+			m.challenge = nil
+			m.notice = ""
+			go SubmitChallenge(msg.Content)
+		}
+
+	case JumpFailedMessage:
+		m.notice = fmt.Sprintf("cannot interview %s: %v", msg.Topic, msg.Err)
+
+	case ScoresMessage:
+		m.sidebar.setCategories(msg.Categories)
+
+	case ResultMessage:
+		m.result = &msg
+		m.choice = nil
+		m.challenge = nil
+		m.notice = ""
+		m.messages = append(m.messages, Message{
+			Content: msg.Content(),
+			IsUser:  false,
+		})
+		m.textarea.Blur()
+		m.layout()
+		m.updateViewport()
+
 	case NewCategoryMessage:
+		m.result = nil
+		m.disputing = false
+		m.appealing = false
+		m.sidebar.setActive(msg.MainIndex, msg.SubIndex)
+		m.header.topic = msg
+		m.header.asked = 0
+		m.selfAssessment = nil
+		m.challenge = nil
+		m.choice = nil
+		m.layout()
+		m.notice = ""
+		m.textarea.Placeholder = defaultPlaceholder
 		m.textarea.Reset()
 		m.clearHistory()
 		m.messages = nil
+		m.selecting = false
 		m.updateViewport()
 
 	case AiMessage:
+		m.header.asked = max(m.header.asked, msg.Asked)
+		content := msg.Content
+		if isChoiceQuestion(msg.QuestionType, msg.Options) {
+			m.choice = newChoice(msg.QuestionType, msg.Options)
+			m.layout()
+			// keep the options in the conversation after the list is gone
+			for i, option := range msg.Options {
+				content += fmt.Sprintf("\n- %s) %s", optionLetter(i), option)
+			}
+		}
 		m.messages = append(m.messages, Message{
-			Content: msg.Content,
+			Content: content,
 			IsUser:  false,
 		})
 		m.viewport.GotoBottom()
 		m.updateViewport()
 
 	case AiThinkingMessage:
+		m.thinking = msg.Thinking
 		if msg.Thinking {
 			m.textarea.Reset()
 			m.clearHistory()
@@ -169,7 +450,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	func() {
-		if _, ok := msg.(tea.MouseMsg); ok {
+		if msg, ok := msg.(tea.MouseMsg); ok {
+			if m.sidebar.open && msg.X < sidebarWidth {
+				if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+					m.sidebar.click(msg.Y - headerHeight)
+				}
+				return
+			}
 			var cmd tea.Cmd
 			m.viewport, cmd = m.viewport.Update(msg)
 			cmds = append(cmds, cmd)
@@ -179,17 +466,169 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// layout sizes the viewport to the space left by the header and the input area.
+func (m *model) layout() {
+	inputHeight := m.textarea.Height() + 4 // +4 for borders and help
+	if m.choice != nil {
+		inputHeight = m.choice.height() + 4
+	}
+	m.viewport.Width = m.width
+	if m.sidebar.open {
+		m.viewport.Width = max(m.width-sidebarWidth, 1)
+	}
+	m.viewport.Height = max(m.height-inputHeight-headerHeight, 1)
+	m.sidebar.setHeight(m.viewport.Height)
+}
+
+// updateSidebar handles the keys while the sidebar is open.
+func (m model) updateSidebar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Up):
+		m.sidebar.move(-1)
+	case key.Matches(msg, keys.Down):
+		m.sidebar.move(1)
+	case key.Matches(msg, keys.Topics, keys.Close):
+		m.sidebar.open = false
+		m.notice = ""
+		m.layout()
+		m.updateViewport()
+	case key.Matches(msg, keys.Select):
+		item := m.sidebar.selected()
+		if item.main == m.sidebar.activeMain && item.sub == m.sidebar.activeSub {
+			m.notice = "this topic is already being interviewed"
+			return m, nil
+		}
+		if m.thinking {
+			m.notice = "wait for the interviewer to finish before switching topics"
+			return m, nil
+		}
+		m.sidebar.open = false
+		m.notice = ""
+		m.layout()
+		go JumpTo(item.main, item.sub)
+	}
+	return m, nil
+}
+
+// updateSelection handles the keys while a message is selected for copying.
+func (m model) updateSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.messages) == 0 {
+		m.selecting = false
+		return m, nil
+	}
+	m.selected = clamp(0, m.selected, len(m.messages)-1)
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Up):
+		m.selected = max(m.selected-1, 0)
+	case key.Matches(msg, keys.Down):
+		m.selected = min(m.selected+1, len(m.messages)-1)
+	case key.Matches(msg, keys.Copy):
+		if err := copyToClipboard(m.messages[m.selected].Content); err != nil {
+			m.notice = "copy failed: " + err.Error()
+			return m, nil
+		}
+		m.selecting = false
+		m.status = "copied message to the clipboard"
+	case key.Matches(msg, keys.Close, keys.SelectMessage):
+		m.selecting = false
+	}
+	m.updateViewport()
+	return m, nil
+}
+
+// updateResult handles the keys while the rating of a topic is shown.
+func (m model) updateResult(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Accept):
+		m.result = nil
+		m.notice = ""
+		go Accept()
+	case key.Matches(msg, keys.Retake):
+		m.result = nil
+		m.notice = ""
+		go Retake()
+	case key.Matches(msg, keys.Appeal):
+		if !m.result.Appealable {
+			m.notice = "this rating cannot be appealed"
+			return m, nil
+		}
+		m.result = nil
+		m.appealing = true
+		m.notice = ""
+		m.textarea.Placeholder = "Argue why the rating should change..."
+		m.textarea.Focus()
+	case key.Matches(msg, keys.Dispute):
+		if !m.result.Disputable {
+			m.notice = "offline ratings cannot be disputed, retake the topic instead"
+			return m, nil
+		}
+		m.result = nil
+		m.disputing = true
+		m.notice = ""
+		m.textarea.Placeholder = "Explain why you disagree with the rating..."
+		m.textarea.Focus()
+	}
+	return m, nil
+}
+
+// updateChoice handles the keys while a choice question is shown.
+func (m model) updateChoice(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, keys.Up):
+		m.choice.up()
+	case key.Matches(msg, keys.Down):
+		m.choice.down()
+	case key.Matches(msg, keys.MoveUp):
+		m.choice.move(-1)
+	case key.Matches(msg, keys.MoveDown):
+		m.choice.move(1)
+	case key.Matches(msg, keys.Toggle):
+		m.choice.toggle()
+	case key.Matches(msg, keys.TextAnswer): // answer in the textarea instead
+		m.choice = nil
+		m.notice = ""
+		m.layout()
+		m.updateViewport()
+		m.textarea.Focus()
+	case key.Matches(msg, keys.Send, keys.Select):
+		answer := m.choice.answer()
+		if answer == "" {
+			m.notice = "select an option first"
+			return m, nil
+		}
+		m.choice = nil
+		m.notice = ""
+		m.messages = append(m.messages, Message{
+			Content: answer,
+			IsUser:  true,
+		})
+		m.layout()
+		m.updateViewport()
+
+		go Continue(answer)
+	}
+	return m, nil
+}
+
 func (m *model) updateViewport() {
 	// Update renderer width
 	m.markdownRenderer, _ = glamour.NewTermRenderer(
-		glamour.WithStylePath("dark"),
-		glamour.WithWordWrap(m.width/2-10),
+		glamour.WithStylePath(theme.Markdown),
+		glamour.WithWordWrap(m.viewport.Width/2-10),
 	)
 
 	// Styles
 	userStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("8")).
+		BorderForeground(theme.Dim).
 		Padding(0).
 		MarginRight(1).
 		MarginBottom(1).
@@ -201,56 +640,127 @@ func (m *model) updateViewport() {
 		MarginBottom(1).
 		Align(lipgloss.Left)
 
+	// The selected message is marked with a bar on its left
+	selectedStyle := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(theme.Accent)
+
 	// Render all messages
 	var messageViews []string
+	m.messageLines = m.messageLines[:0]
+	line := 0
 
-	for _, msg := range m.messages {
+	width := m.viewport.Width
+	for i, msg := range m.messages {
 		var content string
+		selected := m.selecting && i == m.selected
 
 		if msg.IsUser {
 			rendered, err := m.markdownRenderer.Render(msg.Content)
 			if err != nil {
 				rendered = msg.Content
 			}
-			content = userStyle.Width(m.width/2 - 4).Render(strings.TrimSpace(rendered))
+			content = userStyle.Width(width/2 - 4).Render(strings.TrimSpace(rendered))
+			if selected {
+				content = selectedStyle.Render(content)
+			}
 			messageViews = append(messageViews,
-				lipgloss.NewStyle().Width(m.width).Align(lipgloss.Right).Render(content))
+				lipgloss.NewStyle().Width(width).Align(lipgloss.Right).Render(content))
 		} else {
 			rendered, err := m.markdownRenderer.Render(msg.Content)
 			if err != nil {
 				rendered = msg.Content
 			}
-			content = llmStyle.Width(m.width/2 - 4).Render(strings.TrimSpace(rendered))
+			content = llmStyle.Width(width/2 - 4).Render(strings.TrimSpace(rendered))
+			if selected {
+				content = selectedStyle.Render(content)
+			}
 			messageViews = append(messageViews,
-				lipgloss.NewStyle().Width(m.width).Align(lipgloss.Left).Render(content))
+				lipgloss.NewStyle().Width(width).Align(lipgloss.Left).Render(content))
 		}
+		m.messageLines = append(m.messageLines, line)
+		line += lipgloss.Height(messageViews[i])
 	}
 
 	// Set viewport content
 	m.viewport.SetContent(strings.Join(messageViews, "\n"))
 
+	if m.selecting && m.selected < len(m.messageLines) {
+		m.viewport.SetYOffset(m.messageLines[m.selected])
+		return
+	}
+
 	// Auto-scroll to bottom
 	m.viewport.GotoBottom()
 }
 
 func (m model) View() string {
+	if m.browser != nil {
+		return m.browser.View()
+	}
+
 	// Input area
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("8")).
+		BorderForeground(theme.Dim).
 		Padding(0, 1)
 
 	inputView := inputStyle.Render(m.textarea.View())
+	if m.choice != nil {
+		inputView = inputStyle.Width(m.width - 2).Render(m.choice.view(m.width - 8))
+	}
 
 	// Help text
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")).
+		Foreground(theme.Dim).
 		MarginTop(1)
 
-	helpView := helpStyle.Render("Enter: new line • Ctrl+Y: send • Ctrl+C: quit • mouse wheel: scroll")
+	mainHelp := newHelp()
+	mainHelp.Width = m.width
+	help := mainHelp.View(keys)
+	if m.choice != nil {
+		help = m.choice.help()
+	}
+	if m.result != nil {
+		appeal, dispute := keys.Appeal, keys.Dispute
+		appeal.SetEnabled(m.result.Appealable)
+		dispute.SetEnabled(m.result.Disputable)
+		help = helpView(keys.Accept, appeal, dispute, keys.Retake, keys.Quit)
+	}
+	if m.sidebar.open {
+		help = m.sidebar.help()
+	}
+	if m.selecting {
+		help = helpView(keys.Up, keys.Down, keys.Copy, keys.Close, keys.Quit)
+	}
+	// the help bubble lets the last item through if not even the ellipsis fits
+	helpView := helpStyle.MaxWidth(m.width).Render(help)
+	if m.status != "" {
+		helpView = helpStyle.Foreground(theme.Accent).Render(m.status)
+	}
+	if m.notice != "" {
+		helpView = helpStyle.Foreground(theme.Error).Render(m.notice)
+	}
+
+	conversation := m.viewport.View()
+	if m.sidebar.open {
+		conversation = lipgloss.JoinHorizontal(lipgloss.Top, m.sidebar.view(), conversation)
+	}
 
 	// Combine all parts
-	return fmt.Sprintf("%s\n%s\n%s", m.viewport.View(), inputView, helpView)
+	return fmt.Sprintf("%s\n%s\n%s\n%s", m.header.view(m.width), conversation, inputView, helpView)
+}
+
+// parseSelfScore reads a self rating from the input, an empty input skips the self assessment and returns 0.
+func parseSelfScore(input string, sa SelfAssessmentMessage) (int, error) {
+	if input == "" {
+		return 0, nil
+	}
+	score, err := strconv.Atoi(input)
+	if err != nil || score < sa.Min || score > sa.Max {
+		return 0, fmt.Errorf("please enter a whole number from %d to %d, or nothing to skip", sa.Min, sa.Max)
+	}
+	return score, nil
 }
 
 // Compress text using gzip